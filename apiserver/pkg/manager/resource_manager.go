@@ -23,6 +23,8 @@ type ResourceManagerInterface interface {
 	GetCluster(ctx context.Context, clusterName string) (*v1alpha1.RayCluster, error)
 	ListClusters(ctx context.Context) ([]*v1alpha1.RayCluster, error)
 	DeleteCluster(ctx context.Context, clusterName string) error
+	SuspendCluster(ctx context.Context, clusterName string) (*v1alpha1.RayCluster, error)
+	ResumeCluster(ctx context.Context, clusterName string) (*v1alpha1.RayCluster, error)
 	CreateComputeTemplate(ctx context.Context, runtime *api.ComputeTemplate) (*v1.ConfigMap, error)
 	GetComputeTemplate(ctx context.Context, name string) (*v1.ConfigMap, error)
 	ListComputeTemplates(ctx context.Context) ([]*v1.ConfigMap, error)
@@ -148,6 +150,35 @@ func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string)
 	return nil
 }
 
+func (r *ResourceManager) SuspendCluster(ctx context.Context, clusterName string) (*v1alpha1.RayCluster, error) {
+	return r.setClusterSuspend(ctx, clusterName, true)
+}
+
+func (r *ResourceManager) ResumeCluster(ctx context.Context, clusterName string) (*v1alpha1.RayCluster, error) {
+	return r.setClusterSuspend(ctx, clusterName, false)
+}
+
+// setClusterSuspend updates the suspend field of the cluster, the operator deletes or creates the pods accordingly
+func (r *ResourceManager) setClusterSuspend(ctx context.Context, clusterName string, suspend bool) (*v1alpha1.RayCluster, error) {
+	if len(clusterName) == 0 {
+		return nil, util.NewInvalidInputError("clusterName is empty, failed to update the cluster.")
+	}
+
+	client := r.getRayClusterClient(DefaultNamespace)
+	cluster, err := getClusterByName(ctx, client, clusterName)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster failure")
+	}
+
+	cluster.Spec.Suspend = &suspend
+	updatedCluster, err := client.Update(ctx, cluster, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update cluster %v.", clusterName)
+	}
+
+	return updatedCluster, nil
+}
+
 // Compute Runtimes
 func (r *ResourceManager) CreateComputeTemplate(ctx context.Context, runtime *api.ComputeTemplate) (*v1.ConfigMap, error) {
 	_, err := r.GetComputeTemplate(ctx, runtime.Name)
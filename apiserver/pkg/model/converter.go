@@ -26,6 +26,7 @@ func FromCrdToApiCluster(cluster *v1alpha1.RayCluster) *api.Cluster {
 		User:        cluster.Labels[util.RayClusterUserLabelKey],
 		Environment: api.Cluster_Environment(api.Cluster_Environment_value[cluster.Labels[util.RayClusterEnvironmentLabelKey]]),
 		CreatedAt:   &timestamp.Timestamp{Seconds: cluster.CreationTimestamp.Unix()},
+		Suspended:   cluster.Spec.Suspend != nil && *cluster.Spec.Suspend,
 	}
 
 	// loop container and find the resource
@@ -71,6 +71,24 @@ func (s *ClusterServer) DeleteCluster(ctx context.Context, request *api.DeleteCl
 	return &emptypb.Empty{}, nil
 }
 
+// Suspends a Cluster, its pods are deleted and its services are kept.
+func (s *ClusterServer) SuspendCluster(ctx context.Context, request *api.SuspendClusterRequest) (*api.Cluster, error) {
+	cluster, err := s.resourceManager.SuspendCluster(ctx, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Suspend cluster failed.")
+	}
+	return model.FromCrdToApiCluster(cluster), nil
+}
+
+// Resumes a suspended Cluster, its pods are created again.
+func (s *ClusterServer) ResumeCluster(ctx context.Context, request *api.ResumeClusterRequest) (*api.Cluster, error) {
+	cluster, err := s.resourceManager.ResumeCluster(ctx, request.Name)
+	if err != nil {
+		return nil, util.Wrap(err, "Resume cluster failed.")
+	}
+	return model.FromCrdToApiCluster(cluster), nil
+}
+
 func ValidateCreateClusterRequest(request *api.CreateClusterRequest) error {
 	if request.Cluster.Name == "" {
 		return util.NewInvalidInputError("Cluster name is empty. Please specify a valid value.")
@@ -42,6 +42,11 @@ func NewRayCluster(apiCluster *api.Cluster, computeTemplateMap map[string]*api.C
 		},
 	}
 
+	if _, ok := apiCluster.ClusterSpec.HeadGroupSpec.RayStartParams["redis-password"]; !ok {
+		// the operator generates a password secret for the cluster
+		rayCluster.Spec.RedisPassword = &rayclusterapi.RedisPasswordSpec{}
+	}
+
 	for _, spec := range apiCluster.ClusterSpec.WorkerGroupSepc {
 		computeTemplate := computeTemplateMap[spec.ComputeTemplate]
 		workerPodTemplate := buildWorkerPodTemplate(apiCluster, spec, computeTemplate)
@@ -18,6 +18,8 @@ func NewCmdCluster() *cobra.Command {
 	cmd.AddCommand(newCmdList())
 	cmd.AddCommand(newCmdCreate())
 	cmd.AddCommand(newCmdDelete())
+	cmd.AddCommand(newCmdSuspend())
+	cmd.AddCommand(newCmdResume())
 
 	return cmd
 }
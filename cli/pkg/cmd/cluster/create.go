@@ -104,7 +104,7 @@ func createCluster(opts CreateOptions) error {
 	headStartParams["port"] = "6379"
 	headStartParams["dashboard-host"] = "0.0.0.0"
 	headStartParams["node-ip-address"] = "$MY_POD_IP"
-	headStartParams["redis-password"] = "LetMeInRay"
+	// no redis-password param, the password is generated in a secret by the operator
 
 	headSpec := &go_client.HeadGroupSpec{
 		ComputeTemplate: opts.headComputeTemplate,
@@ -115,7 +115,6 @@ func createCluster(opts CreateOptions) error {
 
 	workerStartParams := make(map[string]string)
 	workerStartParams["node-ip-address"] = "$MY_POD_IP"
-	workerStartParams["redis-password"] = "LetMeInRay"
 
 	var workerGroupSpecs []*go_client.WorkerGroupSpec
 	spec := &go_client.WorkerGroupSpec{
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ray-project/kuberay/cli/pkg/cmdutil"
+	"github.com/ray-project/kuberay/proto/go_client"
+	"github.com/spf13/cobra"
+)
+
+func newCmdResume() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume <cluster name>",
+		Short: "Resume a suspended ray cluster by name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resumeCluster(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func resumeCluster(name string) error {
+	// Get gRPC connection
+	conn, err := cmdutil.GetGrpcConn()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// build gRPC client
+	client := go_client.NewClusterServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request := &go_client.ResumeClusterRequest{
+		Name: name,
+	}
+	if _, err := client.ResumeCluster(ctx, request); err != nil {
+		log.Fatalf("could not resume cluster %v", err)
+	}
+
+	log.Printf("cluster %v has been resumed", name)
+	return nil
+}
@@ -0,0 +1,48 @@
+package cluster
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/ray-project/kuberay/cli/pkg/cmdutil"
+	"github.com/ray-project/kuberay/proto/go_client"
+	"github.com/spf13/cobra"
+)
+
+func newCmdSuspend() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "suspend <cluster name>",
+		Short: "Suspend a ray cluster by name, its pods are deleted",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return suspendCluster(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func suspendCluster(name string) error {
+	// Get gRPC connection
+	conn, err := cmdutil.GetGrpcConn()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// build gRPC client
+	client := go_client.NewClusterServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request := &go_client.SuspendClusterRequest{
+		Name: name,
+	}
+	if _, err := client.SuspendCluster(ctx, request); err != nil {
+		log.Fatalf("could not suspend cluster %v", err)
+	}
+
+	log.Printf("cluster %v has been suspended", name)
+	return nil
+}
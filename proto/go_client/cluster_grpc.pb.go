@@ -29,6 +29,11 @@ type ClusterServiceClient interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(ctx context.Context, in *DeleteClusterRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Suspends a Cluster. Its pods are deleted, its services and the cluster
+	// itself are kept.
+	SuspendCluster(ctx context.Context, in *SuspendClusterRequest, opts ...grpc.CallOption) (*Cluster, error)
+	// Resumes a suspended Cluster, its pods are created again.
+	ResumeCluster(ctx context.Context, in *ResumeClusterRequest, opts ...grpc.CallOption) (*Cluster, error)
 }
 
 type clusterServiceClient struct {
@@ -75,6 +80,24 @@ func (c *clusterServiceClient) DeleteCluster(ctx context.Context, in *DeleteClus
 	return out, nil
 }
 
+func (c *clusterServiceClient) SuspendCluster(ctx context.Context, in *SuspendClusterRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/SuspendCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterServiceClient) ResumeCluster(ctx context.Context, in *ResumeClusterRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterService/ResumeCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClusterServiceServer is the server API for ClusterService service.
 // All implementations must embed UnimplementedClusterServiceServer
 // for forward compatibility
@@ -89,6 +112,11 @@ type ClusterServiceServer interface {
 	// avoid unexpected behaviors, delete an cluster's runs and jobs before
 	// deleting the cluster.
 	DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error)
+	// Suspends a Cluster. Its pods are deleted, its services and the cluster
+	// itself are kept.
+	SuspendCluster(context.Context, *SuspendClusterRequest) (*Cluster, error)
+	// Resumes a suspended Cluster, its pods are created again.
+	ResumeCluster(context.Context, *ResumeClusterRequest) (*Cluster, error)
 	mustEmbedUnimplementedClusterServiceServer()
 }
 
@@ -108,6 +136,12 @@ func (UnimplementedClusterServiceServer) ListCluster(context.Context, *ListClust
 func (UnimplementedClusterServiceServer) DeleteCluster(context.Context, *DeleteClusterRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCluster not implemented")
 }
+func (UnimplementedClusterServiceServer) SuspendCluster(context.Context, *SuspendClusterRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendCluster not implemented")
+}
+func (UnimplementedClusterServiceServer) ResumeCluster(context.Context, *ResumeClusterRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeCluster not implemented")
+}
 func (UnimplementedClusterServiceServer) mustEmbedUnimplementedClusterServiceServer() {}
 
 // UnsafeClusterServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -193,6 +227,42 @@ func _ClusterService_DeleteCluster_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClusterService_SuspendCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).SuspendCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/SuspendCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).SuspendCluster(ctx, req.(*SuspendClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterService_ResumeCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterServiceServer).ResumeCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterService/ResumeCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterServiceServer).ResumeCluster(ctx, req.(*ResumeClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClusterService_ServiceDesc is the grpc.ServiceDesc for ClusterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -216,6 +286,14 @@ var ClusterService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCluster",
 			Handler:    _ClusterService_DeleteCluster_Handler,
 		},
+		{
+			MethodName: "SuspendCluster",
+			Handler:    _ClusterService_SuspendCluster_Handler,
+		},
+		{
+			MethodName: "ResumeCluster",
+			Handler:    _ClusterService_ResumeCluster_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster.proto",
@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -20,6 +22,120 @@ type RayClusterSpec struct {
 	RayVersion string `json:"rayVersion,omitempty"`
 	// EnableInTreeAutoscaling indicates whether operator should create in tree autoscaling configs
 	EnableInTreeAutoscaling *bool `json:"enableInTreeAutoscaling,omitempty"`
+	// AutoscalerOptions configures the autoscaler container injected in the head pod when EnableInTreeAutoscaling is true
+	AutoscalerOptions *AutoscalerOptions `json:"autoscalerOptions,omitempty"`
+	// GracefulShutdown adds a finalizer to the cluster so that workers are deleted first and the head is drained
+	// before the RayCluster goes away. When it is not set, cleanup is left to the garbage collector.
+	GracefulShutdown *GracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+	// RedisPassword reads the redis password from a secret instead of the redis-password ray start param.
+	// The password reaches the containers through the REDIS_PASSWORD env var and doesn't show in the pod spec.
+	RedisPassword *RedisPasswordSpec `json:"redisPassword,omitempty"`
+	// Suspend deletes the head and worker pods of the cluster when it is true. The services, the ingress and the
+	// RayCluster itself are kept, setting it back to false brings the cluster up again.
+	Suspend *bool `json:"suspend,omitempty"`
+	// TTLSecondsAfterCreation expires the cluster this many seconds after its creation. A cluster suspended
+	// by its TTL is suspended again when it is resumed, unless the TTL is raised or removed.
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterCreation *int32 `json:"ttlSecondsAfterCreation,omitempty"`
+	// IdleTimeoutSeconds expires the cluster once it has been idle for this many seconds.
+	// Idleness is reported by the idle probe of the operator, see the ray.io/last-activity-time annotation.
+	// +kubebuilder:validation:Minimum=0
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// ExpirationAction is applied when the TTL or the idle timeout expires. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Suspend
+	ExpirationAction ExpirationAction `json:"expirationAction,omitempty"`
+	// BatchScheduler gang schedules the head and the minimum workers of the cluster with a PodGroup
+	BatchScheduler *BatchSchedulerSpec `json:"batchScheduler,omitempty"`
+	// NetworkPolicy isolates the pods of the cluster with a NetworkPolicy created by the operator
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+}
+
+// BatchSchedulerSpec selects the batch scheduler creating the PodGroup of the cluster.
+// The minMember of the PodGroup is the head plus the MinReplicas of the worker groups.
+type BatchSchedulerSpec struct {
+	// Name is volcano or scheduler-plugins, whose PodGroup CRD must be installed
+	// +kubebuilder:validation:Enum=volcano;scheduler-plugins
+	Name string `json:"name"`
+	// Queue is the Volcano queue of the PodGroup
+	Queue string `json:"queue,omitempty"`
+	// PriorityClassName is the priority of the Volcano PodGroup
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// NetworkPolicySpec is the NetworkPolicy of the cluster. The pods of the cluster accept any traffic from each other,
+// traffic to the head ports from the peers listed in From, and traffic to the dashboard from the operator only.
+type NetworkPolicySpec struct {
+	// HeadPorts are the names of the head service ports opened to the peers, e.g. dashboard or client.
+	// Defaults to all the ports of the head service.
+	HeadPorts []string `json:"headPorts,omitempty"`
+	// From lists the namespaces and pods allowed to reach the head ports.
+	From []NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// NetworkPolicyPeer selects pods allowed to reach the head ports.
+// Setting both selectors selects the matching pods of the matching namespaces.
+type NetworkPolicyPeer struct {
+	// NamespaceSelector selects namespaces whose pods are allowed, or only the ones matching PodSelector
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector selects pods of the namespace of the cluster or of the namespaces of NamespaceSelector
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// ExpirationAction is what happens to a cluster once its TTL or idle timeout expired
+type ExpirationAction string
+
+const (
+	// DeleteExpirationAction deletes the RayCluster
+	DeleteExpirationAction ExpirationAction = "Delete"
+	// SuspendExpirationAction sets suspend on the RayCluster, its pods are deleted
+	SuspendExpirationAction ExpirationAction = "Suspend"
+)
+
+// RedisPasswordSpec is the secret holding the redis password of the cluster
+type RedisPasswordSpec struct {
+	// SecretName is the secret holding the password. When it is empty, the operator generates
+	// a secret named <cluster name>-redis-password with a random password.
+	SecretName string `json:"secretName,omitempty"`
+	// SecretKey is the key of the password in the secret. Defaults to password.
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
+// UpscalingMode controls how fast the autoscaler adds workers
+type UpscalingMode string
+
+const (
+	// DefaultUpscalingMode lets the autoscaler add workers at its default rate
+	DefaultUpscalingMode UpscalingMode = "Default"
+	// AggressiveUpscalingMode removes the limit on the number of workers added at once
+	AggressiveUpscalingMode UpscalingMode = "Aggressive"
+	// ConservativeUpscalingMode adds pending workers one at a time
+	ConservativeUpscalingMode UpscalingMode = "Conservative"
+)
+
+// AutoscalerOptions configures the Ray autoscaler container of the head pod
+type AutoscalerOptions struct {
+	// Image of the autoscaler container. Defaults to the image of the ray container of the head pod.
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy of the autoscaler container. Defaults to the one of the ray container of the head pod.
+	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Resources of the autoscaler container. Defaults to 500m CPU and 512Mi memory.
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// IdleTimeoutSeconds is the time a worker must be idle before the autoscaler removes it. Defaults to 60.
+	// +kubebuilder:validation:Minimum=0
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// UpscalingMode can be "Default", "Aggressive" or "Conservative". Defaults to Default.
+	// +kubebuilder:validation:Enum=Default;Aggressive;Conservative
+	UpscalingMode UpscalingMode `json:"upscalingMode,omitempty"`
+}
+
+// GracefulShutdownSpec configures how the head pod is drained when the RayCluster is deleted
+type GracefulShutdownSpec struct {
+	// PreStopCommand is run in the ray container of the head pod before it is stopped. Defaults to `ray stop`.
+	// It is ignored if the head template already defines a preStop hook.
+	PreStopCommand []string `json:"preStopCommand,omitempty"`
+	// TerminationGracePeriodSeconds is the time given to the head pod to drain.
+	// Defaults to the value of the head pod template.
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 }
 
 // HeadGroupSpec are the spec for the head pod
@@ -28,6 +144,8 @@ type HeadGroupSpec struct {
 	ServiceType v1.ServiceType `json:"serviceType"`
 	// EnableIngress indicates whether operator should create ingress object for head service or not.
 	EnableIngress *bool `json:"enableIngress,omitempty"`
+	// Ingress configures the ingress created when EnableIngress is true
+	Ingress *IngressSpec `json:"ingress,omitempty"`
 	// Number of desired pods in this pod group. This is a pointer to distinguish between explicit
 	// zero and not specified. Defaults to 1.
 	Replicas *int32 `json:"replicas"`
@@ -35,6 +153,48 @@ type HeadGroupSpec struct {
 	RayStartParams map[string]string `json:"rayStartParams"`
 	// Template is the eaxct pod template used in K8s depoyments, statefulsets, etc.
 	Template v1.PodTemplateSpec `json:"template"`
+	// RestartWorkersOnRecovery deletes the workers created before the head pod was recovered, once the new head
+	// is ready. They are recreated and connect to the new GCS.
+	RestartWorkersOnRecovery bool `json:"restartWorkersOnRecovery,omitempty"`
+	// PodDisruptionBudget protects the head pod from voluntary evictions, e.g. node drains
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudgetSpec is the PodDisruptionBudget created by the operator for the pods of a group.
+// Only one of MinAvailable and MaxUnavailable can be set.
+type PodDisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods of the group that must stay available during evictions
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// MaxUnavailable is the number or percentage of pods of the group that can be evicted at once
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// IngressPort is a port of the head service that can be exposed through the ingress
+// +kubebuilder:validation:Enum=dashboard;client;serve
+type IngressPort string
+
+const (
+	DashboardIngressPort IngressPort = "dashboard"
+	ClientIngressPort    IngressPort = "client"
+	ServeIngressPort     IngressPort = "serve"
+)
+
+// IngressSpec is the ingress configuration of the head service
+type IngressSpec struct {
+	// Host is the host of the ingress rule. All hosts are matched when it is empty.
+	Host string `json:"host,omitempty"`
+	// PathPrefix is the path of the dashboard, the other ports are exposed under <PathPrefix>/<port>.
+	// Defaults to /<cluster name>.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	// PathType is the type of the ingress paths. Defaults to Exact.
+	// +kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+	// TLSSecretName is the secret holding the TLS certificate of Host. TLS is disabled when it is empty.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Ports are the head service ports to expose. Defaults to dashboard.
+	Ports []IngressPort `json:"ports,omitempty"`
+	// Annotations are added to the ingress only, e.g. ingress controller settings
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // WorkerGroupSpec are the specs for the worker pods
@@ -54,12 +214,64 @@ type WorkerGroupSpec struct {
 	Template v1.PodTemplateSpec `json:"template"`
 	//ScaleStrategy defines which pods to remove
 	ScaleStrategy ScaleStrategy `json:"scaleStrategy,omitempty"`
+	// UpdateStrategy defines how pods are replaced when Template or RayStartParams change
+	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+	// PodDisruptionBudget limits the number of pods of the group evicted at once, e.g. by node drains
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 }
 
 // ScaleStrategy to remove workers
 type ScaleStrategy struct {
 	// WorkersToDelete workers to be deleted
 	WorkersToDelete []string `json:"workersToDelete,omitempty"`
+	// ScaleDownPolicy chooses the workers removed on scale down once WorkersToDelete are deleted.
+	// Defaults to PendingFirst.
+	// +kubebuilder:validation:Enum=PendingFirst;NewestFirst;OldestFirst;DeletionCost
+	ScaleDownPolicy ScaleDownPolicy `json:"scaleDownPolicy,omitempty"`
+}
+
+// ScaleDownPolicy is the order in which the workers of a group are removed on scale down
+type ScaleDownPolicy string
+
+const (
+	// PendingFirstScaleDownPolicy removes the pods that are not running or not ready first, then the newest ones
+	PendingFirstScaleDownPolicy ScaleDownPolicy = "PendingFirst"
+	// NewestFirstScaleDownPolicy removes the most recently created pods first
+	NewestFirstScaleDownPolicy ScaleDownPolicy = "NewestFirst"
+	// OldestFirstScaleDownPolicy removes the least recently created pods first
+	OldestFirstScaleDownPolicy ScaleDownPolicy = "OldestFirst"
+	// DeletionCostScaleDownPolicy removes the pods with the lowest ray.io/deletion-cost annotation first,
+	// ties are broken with PendingFirst
+	DeletionCostScaleDownPolicy ScaleDownPolicy = "DeletionCost"
+)
+
+// UpdateStrategyType is the way outdated pods of a worker group are replaced
+type UpdateStrategyType string
+
+const (
+	// RecreateUpdateStrategyType deletes all the outdated pods at once before creating new ones
+	RecreateUpdateStrategyType UpdateStrategyType = "Recreate"
+	// RollingUpdateStrategyType replaces the outdated pods progressively
+	RollingUpdateStrategyType UpdateStrategyType = "RollingUpdate"
+)
+
+// UpdateStrategy to replace outdated workers
+type UpdateStrategy struct {
+	// Type can be "Recreate" or "RollingUpdate". Defaults to RollingUpdate.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	Type UpdateStrategyType `json:"type,omitempty"`
+	// RollingUpdate configures the replacement when Type is RollingUpdate
+	RollingUpdate *RollingUpdateStrategy `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateStrategy controls the pace of a rolling update
+type RollingUpdateStrategy struct {
+	// MaxUnavailable is the number or percentage of desired workers that can be unavailable during the update.
+	// Defaults to 25%.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the number or percentage of workers that can be created above the desired replicas during the update.
+	// Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 }
 
 // The overall state of the Ray cluster.
@@ -69,6 +281,25 @@ const (
 	Ready     ClusterState = "ready"
 	UnHealthy ClusterState = "unHealthy"
 	Failed    ClusterState = "failed"
+	Suspended ClusterState = "suspended"
+)
+
+// RayClusterConditionType is the type of a condition reported in RayClusterStatus
+type RayClusterConditionType string
+
+const (
+	// HeadPodReady means the head pod is running and its ray container is ready
+	HeadPodReady RayClusterConditionType = "HeadPodReady"
+	// HeadServiceReady means the head service exists
+	HeadServiceReady RayClusterConditionType = "HeadServiceReady"
+	// IngressReady means the ingress of the head service exists
+	IngressReady RayClusterConditionType = "IngressReady"
+	// WorkersReady means every worker group has as many running pods as desired
+	WorkersReady RayClusterConditionType = "WorkersReady"
+	// ReconcileError means the last reconciliation of the cluster failed
+	ReconcileError RayClusterConditionType = "ReconcileError"
+	// Provisioned becomes true the first time the cluster is ready and is never reset
+	Provisioned RayClusterConditionType = "Provisioned"
 )
 
 // RayClusterStatus defines the observed state of RayCluster
@@ -77,6 +308,11 @@ type RayClusterStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	// Status reflects the status of the cluster
 	State ClusterState `json:"state,omitempty"`
+	// Reason provides more information about current State
+	Reason string `json:"reason,omitempty"`
+	// LastStateTransitionTime indicates the last time State was changed.
+	// +nullable
+	LastStateTransitionTime metav1.Time `json:"lastStateTransitionTime,omitempty"`
 	// AvailableWorkerReplicas indicates how many replicas are available in the cluster
 	AvailableWorkerReplicas int32 `json:"availableWorkerReplicas,omitempty"`
 	// DesiredWorkerReplicas indicates overall desired replicas claimed by the user at the cluster level.
@@ -88,6 +324,42 @@ type RayClusterStatus struct {
 	// LastUpdateTime indicates last update timestamp for this cluster status.
 	// +nullable
 	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+	// HeadRestarts is the number of times the operator recreated a failed or succeeded head pod.
+	HeadRestarts int32 `json:"headRestarts,omitempty"`
+	// LastHeadRestartTime is the last time the operator recreated the head pod.
+	// +optional
+	LastHeadRestartTime *metav1.Time `json:"lastHeadRestartTime,omitempty"`
+	// Conditions represent the latest available observations of the cluster's state.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// WorkerGroupStatuses are the failures observed for each worker group
+	// +optional
+	// +listType=map
+	// +listMapKey=groupName
+	WorkerGroupStatuses []WorkerGroupStatus `json:"workerGroupStatuses,omitempty"`
+}
+
+// WorkerGroupStatus tracks the worker pods of a group which failed or succeeded
+type WorkerGroupStatus struct {
+	// GroupName is the name of the worker group
+	GroupName string `json:"groupName"`
+	// Failures is the number of worker pods of the group which failed
+	Failures int32 `json:"failures,omitempty"`
+	// RecentFailures is the number of worker pods which failed since FailureWindowStart
+	RecentFailures int32 `json:"recentFailures,omitempty"`
+	// FailureWindowStart is the time of the first of the recent failures
+	// +optional
+	FailureWindowStart *metav1.Time `json:"failureWindowStart,omitempty"`
+	// LastFailureTime is the last time a worker pod of the group failed
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// CrashLooping is true when too many worker pods of the group failed within the failure window.
+	// The flag is reset once the group runs without failure for the duration of the window.
+	CrashLooping bool `json:"crashLooping,omitempty"`
 }
 
 // RayNodeType  the type of a ray node: head/worker
@@ -100,6 +372,14 @@ const (
 	WorkerNode RayNodeType = "worker"
 )
 
+// Labels set by the operator on the ray pods
+const (
+	// RayClusterLabelKey is the name of the cluster of the pod
+	RayClusterLabelKey = "ray.io/cluster"
+	// RayNodeGroupLabelKey is the name of the group of the pod
+	RayNodeGroupLabelKey = "ray.io/group"
+)
+
 // RayCluster is the Schema for the RayClusters API
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -0,0 +1,211 @@
+package v1alpha1
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// validatingWebhookPath is the path of the validating webhook of RayCluster, it matches the kubebuilder marker below
+const validatingWebhookPath = "/validate-ray-io-v1alpha1-raycluster"
+
+var rayclusterlog = logf.Log.WithName("raycluster-resource")
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks of RayCluster with the manager
+func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(validatingWebhookPath, &webhook.Admission{Handler: &RayClusterValidator{Client: mgr.GetClient()}})
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-ray-io-v1alpha1-raycluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1alpha1,name=mraycluster.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Defaulter = &RayCluster{}
+
+// Default fills in the documented defaults: replicas=1, minReplicas=1 and maxReplicas=MaxInt32
+func (r *RayCluster) Default() {
+	rayclusterlog.Info("default", "name", r.Name)
+
+	if r.Spec.HeadGroupSpec.Replicas == nil {
+		r.Spec.HeadGroupSpec.Replicas = pointer.Int32Ptr(1)
+	}
+	for index := range r.Spec.WorkerGroupSpecs {
+		worker := &r.Spec.WorkerGroupSpecs[index]
+		if worker.Replicas == nil {
+			worker.Replicas = pointer.Int32Ptr(1)
+		}
+		if worker.MinReplicas == nil {
+			worker.MinReplicas = pointer.Int32Ptr(1)
+		}
+		if worker.MaxReplicas == nil {
+			worker.MaxReplicas = pointer.Int32Ptr(math.MaxInt32)
+		}
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-ray-io-v1alpha1-raycluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=ray.io,resources=rayclusters,verbs=create;update,versions=v1alpha1,name=vraycluster.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// RayClusterValidator validates the created and updated RayClusters.
+// Client is used to look up the pods listed in WorkersToDelete, the check is skipped when it is nil.
+type RayClusterValidator struct {
+	Client  client.Reader
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &RayClusterValidator{}
+var _ admission.DecoderInjector = &RayClusterValidator{}
+
+// InjectDecoder implements admission.DecoderInjector, the webhook server injects the decoder on registration
+func (v *RayClusterValidator) InjectDecoder(decoder *admission.Decoder) error {
+	v.decoder = decoder
+	return nil
+}
+
+// Handle implements admission.Handler
+func (v *RayClusterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation == admissionv1.Delete {
+		return admission.Allowed("")
+	}
+	cluster := &RayCluster{}
+	if err := v.decoder.Decode(req, cluster); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	rayclusterlog.Info("validate "+string(req.Operation), "name", cluster.Name)
+
+	if err := v.ValidateRayCluster(ctx, cluster); err != nil {
+		var statusErr *apierrors.StatusError
+		if goerrors.As(err, &statusErr) {
+			return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &statusErr.ErrStatus}}
+		}
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// ValidateRayCluster validates the spec of the cluster, the errors are reported together as an Invalid error
+func (v *RayClusterValidator) ValidateRayCluster(ctx context.Context, r *RayCluster) error {
+	allErrs := r.validateRayCluster()
+	if v.Client != nil {
+		for index, worker := range r.Spec.WorkerGroupSpecs {
+			path := field.NewPath("spec", "workerGroupSpecs").Index(index).Child("scaleStrategy", "workersToDelete")
+			allErrs = append(allErrs, v.validateWorkersToDelete(ctx, path, r, worker)...)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: GroupVersion.Group, Kind: "RayCluster"}, r.Name, allErrs)
+}
+
+func (r *RayCluster) validateRayCluster() field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	headPath := specPath.Child("headGroupSpec")
+	allErrs = append(allErrs, validateReplicas(headPath.Child("replicas"), r.Spec.HeadGroupSpec.Replicas)...)
+	if len(r.Spec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(headPath.Child("template", "spec", "containers"),
+			"the head pod needs at least one container"))
+	}
+	allErrs = append(allErrs, validatePodDisruptionBudget(headPath.Child("podDisruptionBudget"), r.Spec.HeadGroupSpec.PodDisruptionBudget)...)
+
+	groupNames := map[string]bool{}
+	for index, worker := range r.Spec.WorkerGroupSpecs {
+		workerPath := specPath.Child("workerGroupSpecs").Index(index)
+		if worker.GroupName == "" {
+			allErrs = append(allErrs, field.Required(workerPath.Child("groupName"), ""))
+		} else if groupNames[worker.GroupName] {
+			allErrs = append(allErrs, field.Duplicate(workerPath.Child("groupName"), worker.GroupName))
+		}
+		groupNames[worker.GroupName] = true
+
+		allErrs = append(allErrs, validateReplicas(workerPath.Child("replicas"), worker.Replicas)...)
+		if worker.MinReplicas != nil && *worker.MinReplicas < 0 {
+			allErrs = append(allErrs, field.Invalid(workerPath.Child("minReplicas"), *worker.MinReplicas, "must be greater than or equal to 0"))
+		}
+		if worker.MinReplicas != nil && worker.MaxReplicas != nil && *worker.MinReplicas > *worker.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(workerPath.Child("minReplicas"), *worker.MinReplicas,
+				fmt.Sprintf("must be less than or equal to maxReplicas (%d)", *worker.MaxReplicas)))
+		} else if worker.Replicas != nil {
+			if worker.MinReplicas != nil && *worker.Replicas < *worker.MinReplicas {
+				allErrs = append(allErrs, field.Invalid(workerPath.Child("replicas"), *worker.Replicas,
+					fmt.Sprintf("must be greater than or equal to minReplicas (%d)", *worker.MinReplicas)))
+			}
+			if worker.MaxReplicas != nil && *worker.Replicas > *worker.MaxReplicas {
+				allErrs = append(allErrs, field.Invalid(workerPath.Child("replicas"), *worker.Replicas,
+					fmt.Sprintf("must be less than or equal to maxReplicas (%d)", *worker.MaxReplicas)))
+			}
+		}
+		if len(worker.Template.Spec.Containers) == 0 {
+			allErrs = append(allErrs, field.Required(workerPath.Child("template", "spec", "containers"),
+				"the worker pods need at least one container"))
+		}
+		allErrs = append(allErrs, validatePodDisruptionBudget(workerPath.Child("podDisruptionBudget"), worker.PodDisruptionBudget)...)
+	}
+
+	if r.Spec.NetworkPolicy != nil {
+		fromPath := specPath.Child("networkPolicy", "from")
+		for index, peer := range r.Spec.NetworkPolicy.From {
+			if peer.NamespaceSelector == nil && peer.PodSelector == nil {
+				allErrs = append(allErrs, field.Required(fromPath.Index(index), "namespaceSelector or podSelector must be set"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateReplicas(path *field.Path, replicas *int32) field.ErrorList {
+	if replicas == nil {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if *replicas < 0 {
+		return field.ErrorList{field.Invalid(path, *replicas, "must be greater than or equal to 0")}
+	}
+	return nil
+}
+
+func validatePodDisruptionBudget(path *field.Path, budget *PodDisruptionBudgetSpec) field.ErrorList {
+	if budget == nil || budget.MinAvailable == nil || budget.MaxUnavailable == nil {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path.Child("maxUnavailable"), "minAvailable and maxUnavailable cannot be both set")}
+}
+
+// validateWorkersToDelete rejects the workers that belong to another group or cluster.
+// Pods that don't exist anymore are accepted, they may have been deleted already.
+func (v *RayClusterValidator) validateWorkersToDelete(ctx context.Context, path *field.Path, r *RayCluster, worker WorkerGroupSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	for index, podName := range worker.ScaleStrategy.WorkersToDelete {
+		pod := corev1.Pod{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: podName}, &pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			allErrs = append(allErrs, field.InternalError(path.Index(index), err))
+			continue
+		}
+		if pod.Labels[RayClusterLabelKey] != r.Name || pod.Labels[RayNodeGroupLabelKey] != worker.GroupName {
+			allErrs = append(allErrs, field.Invalid(path.Index(index), podName,
+				fmt.Sprintf("pod belongs to group %q of cluster %q", pod.Labels[RayNodeGroupLabelKey], pod.Labels[RayClusterLabelKey])))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,137 @@
+package v1alpha1
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefault(t *testing.T) {
+	cluster := myRayCluster.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Replicas = nil
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = nil
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = nil
+	cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = nil
+
+	cluster.Default()
+
+	if *cluster.Spec.HeadGroupSpec.Replicas != 1 {
+		t.Fatalf("Expected `%v` but got `%v`", 1, *cluster.Spec.HeadGroupSpec.Replicas)
+	}
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	if *worker.Replicas != 1 || *worker.MinReplicas != 1 || *worker.MaxReplicas != math.MaxInt32 {
+		t.Fatalf("Expected `%v` but got `%v`", []int32{1, 1, math.MaxInt32}, []int32{*worker.Replicas, *worker.MinReplicas, *worker.MaxReplicas})
+	}
+}
+
+func TestValidateRayCluster(t *testing.T) {
+	validator := &RayClusterValidator{}
+	if err := validator.ValidateRayCluster(context.TODO(), myRayCluster); err != nil {
+		t.Fatalf("Expected `%v` but got `%v`", nil, err)
+	}
+
+	tests := map[string]struct {
+		mutate   func(cluster *RayCluster)
+		expected string
+	}{
+		"nil replicas": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].Replicas = nil
+			},
+			expected: "spec.workerGroupSpecs[0].replicas: Required value",
+		},
+		"min greater than max": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32Ptr(5)
+				cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = pointer.Int32Ptr(2)
+			},
+			expected: "spec.workerGroupSpecs[0].minReplicas: Invalid value: 5: must be less than or equal to maxReplicas (2)",
+		},
+		"replicas less than min": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32Ptr(0)
+				cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32Ptr(1)
+			},
+			expected: "spec.workerGroupSpecs[0].replicas: Invalid value: 0: must be greater than or equal to minReplicas (1)",
+		},
+		"replicas greater than max": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].Replicas = pointer.Int32Ptr(3)
+				cluster.Spec.WorkerGroupSpecs[0].MaxReplicas = pointer.Int32Ptr(2)
+			},
+			expected: "spec.workerGroupSpecs[0].replicas: Invalid value: 3: must be less than or equal to maxReplicas (2)",
+		},
+		"duplicate group name": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, *cluster.Spec.WorkerGroupSpecs[0].DeepCopy())
+			},
+			expected: `spec.workerGroupSpecs[1].groupName: Duplicate value: "small-group"`,
+		},
+		"head without containers": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.HeadGroupSpec.Template.Spec.Containers = nil
+			},
+			expected: "spec.headGroupSpec.template.spec.containers: Required value: the head pod needs at least one container",
+		},
+		"pod disruption budget with both bounds": {
+			mutate: func(cluster *RayCluster) {
+				one := intstr.FromInt(1)
+				cluster.Spec.WorkerGroupSpecs[0].PodDisruptionBudget = &PodDisruptionBudgetSpec{MinAvailable: &one, MaxUnavailable: &one}
+			},
+			expected: "spec.workerGroupSpecs[0].podDisruptionBudget.maxUnavailable: Forbidden: minAvailable and maxUnavailable cannot be both set",
+		},
+		"network policy peer without selectors": {
+			mutate: func(cluster *RayCluster) {
+				cluster.Spec.NetworkPolicy = &NetworkPolicySpec{From: []NetworkPolicyPeer{{}}}
+			},
+			expected: "spec.networkPolicy.from[0]: Required value: namespaceSelector or podSelector must be set",
+		},
+	}
+
+	for name, test := range tests {
+		cluster := myRayCluster.DeepCopy()
+		test.mutate(cluster)
+		err := validator.ValidateRayCluster(context.TODO(), cluster)
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Fatalf("%s: Expected `%v` but got `%v`", name, test.expected, err)
+		}
+	}
+}
+
+func TestValidateWorkersToDelete(t *testing.T) {
+	newPod := func(name string, group string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels: map[string]string{
+					RayClusterLabelKey:   "raycluster-sample",
+					RayNodeGroupLabelKey: group,
+				},
+			},
+		}
+	}
+	validator := &RayClusterValidator{
+		Client: fake.NewClientBuilder().WithObjects(newPod("pod-1", "small-group"), newPod("pod-2", "large-group")).Build(),
+	}
+
+	cluster := myRayCluster.DeepCopy()
+	// pods that don't exist are accepted
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod-1", "pod-3"}
+	if err := validator.ValidateRayCluster(context.TODO(), cluster); err != nil {
+		t.Fatalf("Expected `%v` but got `%v`", nil, err)
+	}
+
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod-2"}
+	expected := `spec.workerGroupSpecs[0].scaleStrategy.workersToDelete[0]: Invalid value: "pod-2": pod belongs to group "large-group"`
+	if err := validator.ValidateRayCluster(context.TODO(), cluster); err == nil || !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, err)
+	}
+}
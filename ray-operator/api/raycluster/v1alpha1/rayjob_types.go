@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobStatus is the status of the ray job, as reported by the job server of the head
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "PENDING"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusStopped   JobStatus = "STOPPED"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusFailed    JobStatus = "FAILED"
+)
+
+// IsJobTerminal returns true if the job won't change anymore
+func IsJobTerminal(status JobStatus) bool {
+	return status == JobStatusStopped || status == JobStatusSucceeded || status == JobStatusFailed
+}
+
+// JobDeploymentStatus is the progress of the RayJob controller
+type JobDeploymentStatus string
+
+const (
+	// JobDeploymentStatusInitializing means the RayCluster of the job is being created
+	JobDeploymentStatusInitializing JobDeploymentStatus = "Initializing"
+	// JobDeploymentStatusFailedToGetOrCreateRayCluster means the RayCluster of the job could not be created
+	JobDeploymentStatusFailedToGetOrCreateRayCluster JobDeploymentStatus = "FailedToGetOrCreateRayCluster"
+	// JobDeploymentStatusWaitForCluster means the head pod of the RayCluster is not ready yet
+	JobDeploymentStatusWaitForCluster JobDeploymentStatus = "WaitForCluster"
+	// JobDeploymentStatusFailedJobDeploy means the job could not be submitted to the job server
+	JobDeploymentStatusFailedJobDeploy JobDeploymentStatus = "FailedJobDeploy"
+	// JobDeploymentStatusRunning means the job was submitted and is tracked until it finishes
+	JobDeploymentStatusRunning JobDeploymentStatus = "Running"
+	// JobDeploymentStatusFailedToGetJobStatus means the status of the job could not be read from the job server
+	JobDeploymentStatusFailedToGetJobStatus JobDeploymentStatus = "FailedToGetJobStatus"
+	// JobDeploymentStatusComplete means the job finished, its RayCluster is deleted once the TTL expires
+	JobDeploymentStatusComplete JobDeploymentStatus = "Complete"
+)
+
+// RayJobSpec defines the desired state of RayJob
+type RayJobSpec struct {
+	// Entrypoint is the command submitted to the job server, e.g. python /home/ray/samples/job.py
+	Entrypoint string `json:"entrypoint"`
+	// JobID is the id of the job in the job server. Defaults to the name of the RayJob followed by a random suffix.
+	JobID string `json:"jobId,omitempty"`
+	// RuntimeEnv is the JSON encoded runtime environment of the job, e.g. {"pip": ["requests"]}
+	RuntimeEnv string `json:"runtimeEnv,omitempty"`
+	// Metadata is passed to the job server with the job
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// TTLSecondsAfterFinished is the time the RayCluster is kept after the job finished, nil keeps it.
+	// The job finished when it succeeded, failed or was stopped. When it is not set, the RayCluster is never
+	// deleted by the operator and is kept until the RayJob is deleted.
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// RayClusterSpec is the spec of the RayCluster created to run the job
+	RayClusterSpec RayClusterSpec `json:"rayClusterSpec"`
+}
+
+// RayJobStatus defines the observed state of RayJob
+type RayJobStatus struct {
+	// JobID is the id of the job in the job server
+	JobID string `json:"jobId,omitempty"`
+	// RayClusterName is the name of the RayCluster running the job
+	RayClusterName string `json:"rayClusterName,omitempty"`
+	// DashboardURL is the address of the job server
+	DashboardURL string `json:"dashboardURL,omitempty"`
+	// JobStatus is the status of the job in the job server
+	JobStatus JobStatus `json:"jobStatus,omitempty"`
+	// JobDeploymentStatus is the progress of the controller
+	JobDeploymentStatus JobDeploymentStatus `json:"jobDeploymentStatus,omitempty"`
+	// Message provides more information about the job or the last error
+	Message string `json:"message,omitempty"`
+	// StartTime is the time the job started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// EndTime is the time the job finished
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+}
+
+// RayJob is the Schema for the RayJobs API
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="job status",type=string,JSONPath=".status.jobStatus"
+//+kubebuilder:printcolumn:name="deployment status",type=string,JSONPath=".status.jobDeploymentStatus"
+//+kubebuilder:printcolumn:name="cluster",type=string,JSONPath=".status.rayClusterName"
+//+kubebuilder:printcolumn:name="age",type=date,JSONPath=".metadata.creationTimestamp"
+type RayJob struct {
+	// Standard object metadata.
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the RayJob.
+	Spec   RayJobSpec   `json:"spec,omitempty"`
+	Status RayJobStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayJobList contains a list of RayJob
+type RayJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayJob{}, &RayJobList{})
+}
@@ -0,0 +1,140 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServeDeploymentHealth is the health of a Serve deployment, as reported by the dashboard of the head
+type ServeDeploymentHealth string
+
+const (
+	ServeDeploymentHealthy   ServeDeploymentHealth = "HEALTHY"
+	ServeDeploymentUpdating  ServeDeploymentHealth = "UPDATING"
+	ServeDeploymentUnhealthy ServeDeploymentHealth = "UNHEALTHY"
+)
+
+// ServeAppRunning is the status of a Serve application whose deployments were all deployed
+const ServeAppRunning = "RUNNING"
+
+// RayServiceState is the progress of the RayService controller
+type RayServiceState string
+
+const (
+	// RayServiceWaitForServeDeploymentReady means the Serve deployments of the active cluster are not healthy yet
+	RayServiceWaitForServeDeploymentReady RayServiceState = "WaitForServeDeploymentReady"
+	// RayServicePreparingNewCluster means a RayCluster with the new spec is brought up while the active one keeps serving
+	RayServicePreparingNewCluster RayServiceState = "PreparingNewCluster"
+	// RayServiceRunning means the Serve deployments of the active cluster are healthy
+	RayServiceRunning RayServiceState = "Running"
+	// RayServiceFailedToGetOrCreateRayCluster means a RayCluster of the service could not be created
+	RayServiceFailedToGetOrCreateRayCluster RayServiceState = "FailedToGetOrCreateRayCluster"
+	// RayServiceFailedServeDeploy means the Serve config could not be deployed or its status could not be read
+	RayServiceFailedServeDeploy RayServiceState = "FailedServeDeploy"
+)
+
+// ServeDeploymentSpec overrides the options of a deployment of the Serve application
+type ServeDeploymentSpec struct {
+	// Name of the deployment in the Serve application
+	Name string `json:"name"`
+	// NumReplicas is the number of replicas of the deployment
+	// +kubebuilder:validation:Minimum=0
+	NumReplicas *int32 `json:"numReplicas,omitempty"`
+	// RoutePrefix is the HTTP route of the deployment, e.g. /classifier
+	RoutePrefix string `json:"routePrefix,omitempty"`
+	// MaxConcurrentQueries is the maximum number of queries sent to a replica of the deployment
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentQueries *int32 `json:"maxConcurrentQueries,omitempty"`
+	// UserConfig is the JSON encoded config passed to the reconfigure method of the deployment
+	UserConfig string `json:"userConfig,omitempty"`
+}
+
+// ServeConfigSpec is the Serve application deployed to the RayCluster through the dashboard
+type ServeConfigSpec struct {
+	// ImportPath is the python path of the Serve application, e.g. fruit.deployment_graph
+	ImportPath string `json:"importPath"`
+	// RuntimeEnv is the JSON encoded runtime environment of the application, e.g. {"working_dir": "https://..."}
+	RuntimeEnv string `json:"runtimeEnv,omitempty"`
+	// Deployments overrides the options of the deployments of the application
+	// +listType=map
+	// +listMapKey=name
+	Deployments []ServeDeploymentSpec `json:"deployments,omitempty"`
+}
+
+// RayServiceSpec defines the desired state of RayService
+type RayServiceSpec struct {
+	// ServeConfig is the Serve application deployed to the RayCluster.
+	// A change is deployed in place to the active RayCluster.
+	ServeConfig ServeConfigSpec `json:"serveConfig"`
+	// RayClusterSpec is the spec of the RayCluster running the Serve application.
+	// A change brings up a new RayCluster, the Serve service is switched to it once its deployments are healthy.
+	RayClusterSpec RayClusterSpec `json:"rayClusterSpec"`
+}
+
+// ServeDeploymentStatus is the health of a Serve deployment
+type ServeDeploymentStatus struct {
+	// Name of the deployment
+	Name string `json:"name"`
+	// Status is HEALTHY, UPDATING or UNHEALTHY
+	Status ServeDeploymentHealth `json:"status,omitempty"`
+	// Message explains why the deployment is not healthy
+	Message string `json:"message,omitempty"`
+	// LastUpdateTime is the last time the status of the deployment changed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// RayClusterServeStatus is the status of the Serve application of a RayCluster of the service
+type RayClusterServeStatus struct {
+	// RayClusterName is the name of the RayCluster
+	RayClusterName string `json:"rayClusterName,omitempty"`
+	// ServeConfigHash is the hash of the Serve config deployed to the RayCluster
+	ServeConfigHash string `json:"serveConfigHash,omitempty"`
+	// AppStatus is the status of the Serve application, e.g. DEPLOYING or RUNNING
+	AppStatus string `json:"appStatus,omitempty"`
+	// Message provides more information about the application or the last error
+	Message string `json:"message,omitempty"`
+	// ServeDeploymentStatuses is the health of each deployment of the application
+	// +listType=map
+	// +listMapKey=name
+	ServeDeploymentStatuses []ServeDeploymentStatus `json:"serveDeploymentStatuses,omitempty"`
+}
+
+// RayServiceStatus defines the observed state of RayService
+type RayServiceStatus struct {
+	// ServiceStatus is the progress of the controller
+	ServiceStatus RayServiceState `json:"serviceStatus,omitempty"`
+	// ActiveServiceStatus is the RayCluster selected by the Serve service
+	ActiveServiceStatus RayClusterServeStatus `json:"activeServiceStatus,omitempty"`
+	// PendingServiceStatus is the RayCluster brought up for the new spec, it replaces the active one once healthy
+	PendingServiceStatus RayClusterServeStatus `json:"pendingServiceStatus,omitempty"`
+}
+
+// RayService is the Schema for the RayServices API
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="service status",type=string,JSONPath=".status.serviceStatus"
+//+kubebuilder:printcolumn:name="active cluster",type=string,JSONPath=".status.activeServiceStatus.rayClusterName"
+//+kubebuilder:printcolumn:name="pending cluster",type=string,JSONPath=".status.pendingServiceStatus.rayClusterName"
+//+kubebuilder:printcolumn:name="age",type=date,JSONPath=".metadata.creationTimestamp"
+type RayService struct {
+	// Standard object metadata.
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of the RayService.
+	Spec   RayServiceSpec   `json:"spec,omitempty"`
+	Status RayServiceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayServiceList contains a list of RayService
+type RayServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayService{}, &RayServiceList{})
+}
@@ -0,0 +1,111 @@
+package batchscheduler
+
+import (
+	"fmt"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// BatchScheduler gang schedules the pods of a RayCluster with a PodGroup: the scheduler binds the pods of
+// the cluster once they can all be placed, instead of leaving a half scheduled cluster holding resources.
+// The PodGroups are handled as unstructured objects so that the operator doesn't depend on the schedulers.
+type BatchScheduler interface {
+	// Name is the value of spec.batchScheduler.name selecting the scheduler
+	Name() string
+	// PodGroupGVK is the kind of the PodGroup of the scheduler
+	PodGroupGVK() schema.GroupVersionKind
+	// BuildPodGroup returns the PodGroup of the cluster, named after the cluster
+	BuildPodGroup(cluster *rayiov1alpha1.RayCluster) *unstructured.Unstructured
+	// AddToPodGroup sets the scheduler name of the pod and adds it to the PodGroup of the cluster
+	AddToPodGroup(cluster *rayiov1alpha1.RayCluster, template *corev1.PodTemplateSpec)
+}
+
+var schedulers = map[string]BatchScheduler{}
+
+// Register makes a scheduler available to spec.batchScheduler.name
+func Register(scheduler BatchScheduler) {
+	schedulers[scheduler.Name()] = scheduler
+}
+
+func init() {
+	Register(&VolcanoScheduler{})
+	Register(&SchedulerPluginsScheduler{})
+}
+
+// GetScheduler returns the scheduler selected by the cluster, or nil if it doesn't use one
+func GetScheduler(cluster *rayiov1alpha1.RayCluster) (BatchScheduler, error) {
+	if cluster.Spec.BatchScheduler == nil {
+		return nil, nil
+	}
+	scheduler, ok := schedulers[cluster.Spec.BatchScheduler.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown batch scheduler %q", cluster.Spec.BatchScheduler.Name)
+	}
+	return scheduler, nil
+}
+
+// GetMinMember returns the number of pods of the cluster scheduled together, the head and the minimum workers
+func GetMinMember(cluster *rayiov1alpha1.RayCluster) int32 {
+	count := int32(1)
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.MinReplicas != nil {
+			count += *worker.MinReplicas
+		}
+	}
+	return count
+}
+
+// GetMinResources returns the resources requested by the head and the minimum workers of the cluster
+func GetMinResources(cluster *rayiov1alpha1.RayCluster) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	addPodResources(total, cluster.Spec.HeadGroupSpec.Template.Spec, 1)
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.MinReplicas != nil {
+			addPodResources(total, worker.Template.Spec, *worker.MinReplicas)
+		}
+	}
+	return total
+}
+
+// addPodResources adds the requests of the containers of the pod count times, the limits stand for missing requests
+func addPodResources(total corev1.ResourceList, spec corev1.PodSpec, count int32) {
+	for _, container := range spec.Containers {
+		requests := container.Resources.Requests.DeepCopy()
+		if requests == nil {
+			requests = corev1.ResourceList{}
+		}
+		for name, limit := range container.Resources.Limits {
+			if _, ok := requests[name]; !ok {
+				requests[name] = limit
+			}
+		}
+		for name, quantity := range requests {
+			sum := total[name]
+			for i := int32(0); i < count; i++ {
+				sum.Add(quantity)
+			}
+			total[name] = sum
+		}
+	}
+}
+
+// newPodGroup returns a PodGroup of the given kind with the minMember and minResources of the cluster
+func newPodGroup(cluster *rayiov1alpha1.RayCluster, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	minResources := map[string]interface{}{}
+	for name, quantity := range GetMinResources(cluster) {
+		minResources[string(name)] = quantity.String()
+	}
+	podGroup := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"minMember":    int64(GetMinMember(cluster)),
+			"minResources": minResources,
+		},
+	}}
+	podGroup.SetGroupVersionKind(gvk)
+	podGroup.SetName(cluster.Name)
+	podGroup.SetNamespace(cluster.Namespace)
+	return podGroup
+}
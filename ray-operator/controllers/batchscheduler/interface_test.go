@@ -0,0 +1,102 @@
+package batchscheduler
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func newContainer(requests corev1.ResourceList, limits corev1.ResourceList) corev1.Container {
+	return corev1.Container{
+		Name:      "ray",
+		Image:     "rayproject/ray:1.12.0",
+		Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits},
+	}
+}
+
+func newCluster(batchScheduler *rayiov1alpha1.BatchSchedulerSpec) *rayiov1alpha1.RayCluster {
+	return &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+		Spec: rayiov1alpha1.RayClusterSpec{
+			BatchScheduler: batchScheduler,
+			HeadGroupSpec: rayiov1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{newContainer(corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					}, nil)}},
+				},
+			},
+			WorkerGroupSpecs: []rayiov1alpha1.WorkerGroupSpec{
+				{
+					GroupName:   "gpu-group",
+					MinReplicas: pointer.Int32Ptr(2),
+					MaxReplicas: pointer.Int32Ptr(8),
+					Template: corev1.PodTemplateSpec{
+						// the limits stand for the missing requests
+						Spec: corev1.PodSpec{Containers: []corev1.Container{newContainer(corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("500m"),
+						}, corev1.ResourceList{
+							corev1.ResourceCPU:              resource.MustParse("1"),
+							"nvidia.com/gpu":                resource.MustParse("4"),
+							corev1.ResourceMemory:           resource.MustParse("8Gi"),
+							corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+						})}},
+					},
+				},
+				{
+					GroupName:   "autoscaled-group",
+					MinReplicas: pointer.Int32Ptr(0),
+					MaxReplicas: pointer.Int32Ptr(8),
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{newContainer(corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("16"),
+						}, nil)}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetScheduler(t *testing.T) {
+	if scheduler, err := GetScheduler(newCluster(nil)); scheduler != nil || err != nil {
+		t.Fatalf("Expected no scheduler but got `%v`, `%v`", scheduler, err)
+	}
+	scheduler, err := GetScheduler(newCluster(&rayiov1alpha1.BatchSchedulerSpec{Name: VolcanoName}))
+	if err != nil || scheduler.Name() != VolcanoName {
+		t.Fatalf("Expected `%v` but got `%v`, `%v`", VolcanoName, scheduler, err)
+	}
+	if _, err := GetScheduler(newCluster(&rayiov1alpha1.BatchSchedulerSpec{Name: "yunikorn"})); err == nil {
+		t.Fatalf("Expected an error for an unknown scheduler")
+	}
+}
+
+func TestGetMinMember(t *testing.T) {
+	if minMember := GetMinMember(newCluster(nil)); minMember != 3 {
+		t.Fatalf("Expected `%v` but got `%v`", 3, minMember)
+	}
+}
+
+func TestGetMinResources(t *testing.T) {
+	expected := map[corev1.ResourceName]string{
+		corev1.ResourceCPU:              "2",
+		corev1.ResourceMemory:           "18Gi",
+		corev1.ResourceEphemeralStorage: "2Gi",
+		"nvidia.com/gpu":                "8",
+	}
+	resources := GetMinResources(newCluster(nil))
+	if len(resources) != len(expected) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, resources)
+	}
+	for name, value := range expected {
+		quantity := resources[name]
+		if quantity.Cmp(resource.MustParse(value)) != 0 {
+			t.Fatalf("Expected %v `%v` but got `%v`", name, value, quantity.String())
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package batchscheduler
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// SchedulerPluginsName selects the coscheduling plugin of scheduler-plugins in spec.batchScheduler.name
+	SchedulerPluginsName = "scheduler-plugins"
+	// SchedulerPluginsSchedulerName is the name of the scheduler deployed by scheduler-plugins as a second scheduler
+	SchedulerPluginsSchedulerName = "scheduler-plugins-scheduler"
+	// SchedulerPluginsPodGroupLabelKey adds a pod to a scheduler-plugins PodGroup
+	SchedulerPluginsPodGroupLabelKey = "pod-group.scheduling.sigs.k8s.io"
+)
+
+// SchedulerPluginsScheduler creates scheduling.sigs.k8s.io PodGroups for the coscheduling plugin,
+// see https://github.com/kubernetes-sigs/scheduler-plugins
+type SchedulerPluginsScheduler struct{}
+
+var _ BatchScheduler = &SchedulerPluginsScheduler{}
+
+// Name implements BatchScheduler
+func (s *SchedulerPluginsScheduler) Name() string {
+	return SchedulerPluginsName
+}
+
+// PodGroupGVK implements BatchScheduler
+func (s *SchedulerPluginsScheduler) PodGroupGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "scheduling.sigs.k8s.io", Version: "v1alpha1", Kind: "PodGroup"}
+}
+
+// BuildPodGroup implements BatchScheduler, the coscheduling plugin has no queues nor priorities
+func (s *SchedulerPluginsScheduler) BuildPodGroup(cluster *rayiov1alpha1.RayCluster) *unstructured.Unstructured {
+	return newPodGroup(cluster, s.PodGroupGVK())
+}
+
+// AddToPodGroup implements BatchScheduler, the coscheduling plugin reads the group from a label
+func (s *SchedulerPluginsScheduler) AddToPodGroup(cluster *rayiov1alpha1.RayCluster, template *corev1.PodTemplateSpec) {
+	template.Spec.SchedulerName = SchedulerPluginsSchedulerName
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[SchedulerPluginsPodGroupLabelKey] = cluster.Name
+}
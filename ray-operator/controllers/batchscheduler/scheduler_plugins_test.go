@@ -0,0 +1,24 @@
+package batchscheduler
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestSchedulerPluginsAddToPodGroup(t *testing.T) {
+	cluster := newCluster(&rayiov1alpha1.BatchSchedulerSpec{Name: SchedulerPluginsName})
+	scheduler := &SchedulerPluginsScheduler{}
+	if apiVersion := scheduler.BuildPodGroup(cluster).GetAPIVersion(); apiVersion != "scheduling.sigs.k8s.io/v1alpha1" {
+		t.Fatalf("Expected `%v` but got `%v`", "scheduling.sigs.k8s.io/v1alpha1", apiVersion)
+	}
+	template := corev1.PodTemplateSpec{}
+	scheduler.AddToPodGroup(cluster, &template)
+	if template.Spec.SchedulerName != SchedulerPluginsSchedulerName {
+		t.Fatalf("Expected `%v` but got `%v`", SchedulerPluginsSchedulerName, template.Spec.SchedulerName)
+	}
+	if group := template.Labels[SchedulerPluginsPodGroupLabelKey]; group != "raycluster-sample" {
+		t.Fatalf("Expected `%v` but got `%v`", "raycluster-sample", group)
+	}
+}
@@ -0,0 +1,52 @@
+package batchscheduler
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// VolcanoName selects Volcano in spec.batchScheduler.name, it is also the name of its scheduler
+	VolcanoName = "volcano"
+	// VolcanoPodGroupAnnotationKey adds a pod to a Volcano PodGroup
+	VolcanoPodGroupAnnotationKey = "scheduling.k8s.io/group-name"
+)
+
+// VolcanoScheduler creates scheduling.volcano.sh PodGroups, see https://volcano.sh
+type VolcanoScheduler struct{}
+
+var _ BatchScheduler = &VolcanoScheduler{}
+
+// Name implements BatchScheduler
+func (s *VolcanoScheduler) Name() string {
+	return VolcanoName
+}
+
+// PodGroupGVK implements BatchScheduler
+func (s *VolcanoScheduler) PodGroupGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "scheduling.volcano.sh", Version: "v1beta1", Kind: "PodGroup"}
+}
+
+// BuildPodGroup implements BatchScheduler, the queue and the priority class of the spec are set on the PodGroup
+func (s *VolcanoScheduler) BuildPodGroup(cluster *rayiov1alpha1.RayCluster) *unstructured.Unstructured {
+	podGroup := newPodGroup(cluster, s.PodGroupGVK())
+	spec := podGroup.Object["spec"].(map[string]interface{})
+	if cluster.Spec.BatchScheduler.Queue != "" {
+		spec["queue"] = cluster.Spec.BatchScheduler.Queue
+	}
+	if cluster.Spec.BatchScheduler.PriorityClassName != "" {
+		spec["priorityClassName"] = cluster.Spec.BatchScheduler.PriorityClassName
+	}
+	return podGroup
+}
+
+// AddToPodGroup implements BatchScheduler
+func (s *VolcanoScheduler) AddToPodGroup(cluster *rayiov1alpha1.RayCluster, template *corev1.PodTemplateSpec) {
+	template.Spec.SchedulerName = VolcanoName
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[VolcanoPodGroupAnnotationKey] = cluster.Name
+}
@@ -0,0 +1,46 @@
+package batchscheduler
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestVolcanoBuildPodGroup(t *testing.T) {
+	cluster := newCluster(&rayiov1alpha1.BatchSchedulerSpec{Name: VolcanoName, Queue: "gpu-queue"})
+	podGroup := (&VolcanoScheduler{}).BuildPodGroup(cluster)
+	if podGroup.GetAPIVersion() != "scheduling.volcano.sh/v1beta1" || podGroup.GetKind() != "PodGroup" {
+		t.Fatalf("Expected a volcano PodGroup but got `%v %v`", podGroup.GetAPIVersion(), podGroup.GetKind())
+	}
+	if podGroup.GetName() != "raycluster-sample" || podGroup.GetNamespace() != "default" {
+		t.Fatalf("Expected `default/raycluster-sample` but got `%v/%v`", podGroup.GetNamespace(), podGroup.GetName())
+	}
+	if minMember, _, _ := unstructured.NestedInt64(podGroup.Object, "spec", "minMember"); minMember != 3 {
+		t.Fatalf("Expected `%v` but got `%v`", 3, minMember)
+	}
+	if gpus, _, _ := unstructured.NestedString(podGroup.Object, "spec", "minResources", "nvidia.com/gpu"); gpus != "8" {
+		t.Fatalf("Expected `%v` but got `%v`", "8", gpus)
+	}
+	if queue, _, _ := unstructured.NestedString(podGroup.Object, "spec", "queue"); queue != "gpu-queue" {
+		t.Fatalf("Expected `%v` but got `%v`", "gpu-queue", queue)
+	}
+	if _, found, _ := unstructured.NestedString(podGroup.Object, "spec", "priorityClassName"); found {
+		t.Fatalf("Expected no priority class")
+	}
+	// DeepCopy panics on the values which are not JSON types, e.g. int32
+	_ = podGroup.DeepCopy()
+}
+
+func TestVolcanoAddToPodGroup(t *testing.T) {
+	cluster := newCluster(&rayiov1alpha1.BatchSchedulerSpec{Name: VolcanoName})
+	template := corev1.PodTemplateSpec{}
+	(&VolcanoScheduler{}).AddToPodGroup(cluster, &template)
+	if template.Spec.SchedulerName != VolcanoName {
+		t.Fatalf("Expected `%v` but got `%v`", VolcanoName, template.Spec.SchedulerName)
+	}
+	if group := template.Annotations[VolcanoPodGroupAnnotationKey]; group != "raycluster-sample" {
+		t.Fatalf("Expected `%v` but got `%v`", "raycluster-sample", group)
+	}
+}
@@ -0,0 +1,141 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+)
+
+// rayStartFlags are the boolean flags of ray start without value, they are left out when false
+var rayStartFlags = map[string]bool{
+	"block":               true,
+	"disable-usage-stats": true,
+	"no-monitor":          true,
+	"no-redirect-output":  true,
+}
+
+// rayStartBoolOptions are the boolean options of ray start taking a value
+var rayStartBoolOptions = map[string]bool{
+	"include-dashboard":   true,
+	"include-log-monitor": true,
+}
+
+var (
+	rayStartParamKey = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	shellSafeValue   = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+	envReference     = regexp.MustCompile(`\$(\{[A-Za-z_][A-Za-z0-9_]*\}|[A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// BuildRayStartCommand builds the ray start command of a node, run by bash. The flags are sorted by name so that
+// the same params always give the same command, and the values are quoted for the shell:
+//   - true and false of the known boolean flags, e.g. block, give a flag without value or no flag
+//   - true and false of the other params are passed as values, e.g. --log-color=false
+//   - JSON objects and arrays, e.g. the custom resources, are compacted and passed as is
+//   - env var references, e.g. $MY_POD_IP, are expanded by the shell
+//   - any other value is passed as is
+func BuildRayStartCommand(nodeType rayiov1alpha1.RayNodeType, rayStartParams map[string]string) string {
+	args := []string{"ray", "start"}
+	switch nodeType {
+	case rayiov1alpha1.HeadNode:
+		args = append(args, "--head")
+	case rayiov1alpha1.WorkerNode:
+	default:
+		log.Error(fmt.Errorf("missing node type"), "a node must be either head or worker")
+		return ""
+	}
+
+	keys := make([]string, 0, len(rayStartParams))
+	for key := range rayStartParams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !rayStartParamKey.MatchString(key) {
+			log.Error(fmt.Errorf("invalid ray start param %q", key), "the param is left out of the ray start command")
+			continue
+		}
+		if flag := buildRayStartFlag(key, rayStartParams[key]); flag != "" {
+			args = append(args, flag)
+		}
+	}
+	return "ulimit -n 65536; " + strings.Join(args, " ")
+}
+
+func buildRayStartFlag(key string, value string) string {
+	value = unquoteParam(value)
+	if lower := strings.ToLower(value); lower == "true" || lower == "false" {
+		switch {
+		case rayStartFlags[key] && lower == "true":
+			return "--" + key
+		case rayStartFlags[key]:
+			return ""
+		case rayStartBoolOptions[key]:
+			return fmt.Sprintf("--%s=%s", key, lower)
+		}
+	}
+	return fmt.Sprintf("--%s=%s", key, quoteParam(value))
+}
+
+// unquoteParam removes the quotes around a value, which used to be needed for values pasted in the command as is,
+// e.g. '"{\"Custom1\": 1}"' for the custom resources
+func unquoteParam(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+	switch {
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1]
+	case value[0] == '"' && value[len(value)-1] == '"':
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// quoteParam quotes a value of a ray start param for bash
+func quoteParam(value string) string {
+	if (strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[")) && json.Valid([]byte(value)) {
+		compacted := bytes.Buffer{}
+		if err := json.Compact(&compacted, []byte(value)); err == nil {
+			value = compacted.String()
+		}
+		return singleQuote(value)
+	}
+	if shellSafeValue.MatchString(value) {
+		return value
+	}
+	if envReference.MatchString(value) {
+		return doubleQuote(value)
+	}
+	return singleQuote(value)
+}
+
+// singleQuote quotes a value for bash, nothing is expanded
+func singleQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
+
+// doubleQuote quotes a value for bash, only the env var references are expanded
+func doubleQuote(value string) string {
+	quoted := strings.Builder{}
+	quoted.WriteByte('"')
+	for index := 0; index < len(value); {
+		if reference := envReference.FindStringIndex(value[index:]); reference != nil && reference[0] == 0 {
+			quoted.WriteString(value[index : index+reference[1]])
+			index += reference[1]
+			continue
+		}
+		switch value[index] {
+		case '"', '\\', '`', '$':
+			quoted.WriteByte('\\')
+		}
+		quoted.WriteByte(value[index])
+		index++
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
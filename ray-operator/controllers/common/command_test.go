@@ -0,0 +1,98 @@
+package common
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of testdata")
+
+func TestBuildRayStartCommand(t *testing.T) {
+	tests := map[string]struct {
+		nodeType rayiov1alpha1.RayNodeType
+		params   map[string]string
+	}{
+		"head": {
+			nodeType: rayiov1alpha1.HeadNode,
+			params: map[string]string{
+				"port":           "6379",
+				"dashboard-host": "0.0.0.0",
+				"num-cpus":       "1",
+				"block":          "true",
+			},
+		},
+		"worker": {
+			nodeType: rayiov1alpha1.WorkerNode,
+			params: map[string]string{
+				"address":         "raycluster-sample-head-svc:6379",
+				"redis-password":  "$REDIS_PASSWORD",
+				"node-ip-address": "$MY_POD_IP",
+				"block":           "true",
+			},
+		},
+		"booleans": {
+			nodeType: rayiov1alpha1.HeadNode,
+			params: map[string]string{
+				"include-dashboard": "False",
+				"no-monitor":        "TRUE",
+				"block":             "false",
+			},
+		},
+		"string-booleans": {
+			nodeType: rayiov1alpha1.WorkerNode,
+			params: map[string]string{
+				"log-color":           "false",
+				"disable-usage-stats": "true",
+				"some-future-option":  "False",
+			},
+		},
+		"json-resources": {
+			nodeType: rayiov1alpha1.WorkerNode,
+			params: map[string]string{
+				"resources": `{"Custom1": 1, "Custom2": 5}`,
+			},
+		},
+		"quoted-json-resources": {
+			nodeType: rayiov1alpha1.WorkerNode,
+			params: map[string]string{
+				"resources": `"{\"Custom1\": 1, \"Custom2\": 5}"`,
+			},
+		},
+		"unsafe-values": {
+			nodeType: rayiov1alpha1.WorkerNode,
+			params: map[string]string{
+				"temp-dir":         "$HOME/ray $(whoami)",
+				"plasma-directory": "/tmp/it's; rm -rf /",
+				"bad key; rm -rf":  "1",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		command := BuildRayStartCommand(test.nodeType, test.params)
+		// the order of the flags doesn't depend on the iteration order of the params
+		for i := 0; i < 10; i++ {
+			if again := BuildRayStartCommand(test.nodeType, test.params); again != command {
+				t.Fatalf("%s: Expected `%v` but got `%v`", name, command, again)
+			}
+		}
+
+		golden := filepath.Join("testdata", "ray_start", name+".golden")
+		if *updateGolden {
+			if err := ioutil.WriteFile(golden, []byte(command+"\n"), 0644); err != nil {
+				t.Fatalf("%s: Failed to update the golden file: %v", name, err)
+			}
+		}
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("%s: Failed to read the golden file: %v", name, err)
+		}
+		if string(expected) != command+"\n" {
+			t.Fatalf("%s: Expected `%v` but got `%v`", name, string(expected), command)
+		}
+	}
+}
@@ -1,12 +1,36 @@
 package common
 
+import rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+
 const (
 	// Belows used as label key
-	RayClusterLabelKey   = "ray.io/cluster"
+	RayClusterLabelKey   = rayiov1alpha1.RayClusterLabelKey
 	RayNodeTypeLabelKey  = "ray.io/node-type"
-	RayNodeGroupLabelKey = "ray.io/group"
+	RayNodeGroupLabelKey = rayiov1alpha1.RayNodeGroupLabelKey
 	RayNodeLabelKey      = "ray.io/is-ray-node"
 	RayIDLabelKey        = "ray.io/identifier"
+	// RayJobLabelKey is set on the RayCluster created for a RayJob
+	RayJobLabelKey = "ray.io/job"
+	// RayServiceLabelKey is set on the RayClusters and the Serve service of a RayService
+	RayServiceLabelKey = "ray.io/service"
+	// KubeRayOperatorLabelKey and KubeRayOperatorLabelValue label the operator pods, the NetworkPolicies let them reach the dashboard
+	KubeRayOperatorLabelKey   = "app.kubernetes.io/name"
+	KubeRayOperatorLabelValue = "kuberay-operator"
+
+	// RayHeadGroupName is the value of the group label of the head pod
+	RayHeadGroupName = "headgroup"
+
+	// Belows used as annotation key
+	RayPodTemplateHashKey = "ray.io/pod-template-hash"
+	// Pods with a lower cost are removed first by the DeletionCost scale down policy
+	RayDeletionCostAnnotationKey = "ray.io/deletion-cost"
+	// RayClusterHashKey is the hash of the spec a RayCluster of a RayService was created from
+	RayClusterHashKey = "ray.io/cluster-hash"
+	// RayLastActivityAnnotationKey is the RFC 3339 time of the last activity on a RayCluster, read by the annotation idle probe
+	RayLastActivityAnnotationKey = "ray.io/last-activity-time"
+
+	// RayClusterFinalizer is added to clusters with graceful shutdown enabled
+	RayClusterFinalizer = "ray.io/graceful-shutdown"
 
 	// Use as separator for pod name, for example, raycluster-small-size-worker-0
 	DashSymbol = "-"
@@ -15,10 +39,20 @@ const (
 	DefaultClientPort    = 10001
 	DefaultRedisPort     = 6379
 	DefaultDashboardPort = 8265
+	DefaultServePort     = 8000
 
 	DefaultClientPortName = "client"
 	DefaultRedisPortName  = "redis"
 	DefaultDashboardName  = "dashboard"
+	DefaultServePortName  = "serve"
+	// the metrics port only exists when the metrics-export-port ray start param is set
+	DefaultMetricsPortName = "metrics"
+
+	// Share of the memory of the ray container given to the object store when object-store-memory is not set, as ray does
+	DefaultObjectStoreMemoryPercent = 30
+
+	// Default command used to drain the head pod before it is stopped
+	DefaultPreStopCommand = "ray stop"
 
 	// Check node if ready by checking the path exists or not
 	PodReadyFilepath = "POD_READY_FILEPATH"
@@ -29,4 +63,8 @@ const (
 	RAY_IP         = "RAY_IP"
 	RAY_PORT       = "RAY_PORT"
 	REDIS_PASSWORD = "REDIS_PASSWORD"
+
+	// Use as env variables of the autoscaler container, set from the AutoscalerOptions of the cluster
+	AUTOSCALER_IDLE_TIMEOUT_SECONDS = "AUTOSCALER_IDLE_TIMEOUT_SECONDS"
+	AUTOSCALER_UPSCALING_MODE       = "AUTOSCALER_UPSCALING_MODE"
 )
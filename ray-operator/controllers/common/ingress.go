@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"path"
+	"reflect"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
@@ -14,39 +16,61 @@ const IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 
 // BuildIngressForHeadService Builds the ingress for head service dashboard.
 // This is used to expose dashboard for external traffic.
+// The dashboard is served under the path prefix and the other ports under <path prefix>/<port name>.
+// Only the ports of the head service can be exposed, e.g. serve must be declared on the ray container of the head.
 func BuildIngressForHeadService(cluster rayiov1alpha1.RayCluster) (*networkingv1.Ingress, error) {
 	labels := map[string]string{
 		RayClusterLabelKey: cluster.Name,
 		RayIDLabelKey:      utils.GenerateIdentifier(cluster.Name, rayiov1alpha1.HeadNode),
 	}
 
-	// Copy other ingress configuration from cluster annotation
-	// This is to provide a generic way for user to customize their ingress settings.
+	ingressSpec := rayiov1alpha1.IngressSpec{}
+	if cluster.Spec.HeadGroupSpec.Ingress != nil {
+		ingressSpec = *cluster.Spec.HeadGroupSpec.Ingress
+	}
+
+	// Only the ingress annotations of the spec are copied, cluster annotations are not meant for the ingress.
 	annotation := map[string]string{}
-	for key, value := range cluster.Annotations {
+	for key, value := range ingressSpec.Annotations {
 		annotation[key] = value
 	}
 
-	var paths []networkingv1.HTTPIngressPath
+	pathPrefix := path.Join("/", ingressSpec.PathPrefix)
+	if ingressSpec.PathPrefix == "" {
+		pathPrefix = "/" + cluster.Name
+	}
 	pathType := networkingv1.PathTypeExact
-	servicePorts := getServicePorts(cluster)
-	dashboardPort := int32(DefaultDashboardPort)
-	if port, ok := servicePorts["dashboard"]; ok {
-		dashboardPort = port
+	if ingressSpec.PathType != nil {
+		pathType = *ingressSpec.PathType
+	}
+	exposedPorts := ingressSpec.Ports
+	if len(exposedPorts) == 0 {
+		exposedPorts = []rayiov1alpha1.IngressPort{rayiov1alpha1.DashboardIngressPort}
 	}
-	paths = []networkingv1.HTTPIngressPath{
-		{
-			Path:     "/" + cluster.Name,
+
+	var paths []networkingv1.HTTPIngressPath
+	servicePorts := getServicePorts(cluster)
+	for _, exposedPort := range exposedPorts {
+		port, ok := servicePorts[string(exposedPort)]
+		if !ok {
+			return nil, fmt.Errorf("port %s can't be exposed through the ingress, it is not a port of the head service", exposedPort)
+		}
+		portPath := pathPrefix
+		if exposedPort != rayiov1alpha1.DashboardIngressPort {
+			portPath = path.Join(pathPrefix, string(exposedPort))
+		}
+		paths = append(paths, networkingv1.HTTPIngressPath{
+			Path:     portPath,
 			PathType: &pathType,
 			Backend: networkingv1.IngressBackend{
 				Service: &networkingv1.IngressServiceBackend{
 					Name: utils.GenerateServiceName(cluster.Name),
 					Port: networkingv1.ServiceBackendPort{
-						Number: dashboardPort,
+						Number: port,
 					},
 				},
 			},
-		},
+		})
 	}
 
 	ingress := &networkingv1.Ingress{
@@ -59,6 +83,7 @@ func BuildIngressForHeadService(cluster rayiov1alpha1.RayCluster) (*networkingv1
 		Spec: networkingv1.IngressSpec{
 			Rules: []networkingv1.IngressRule{
 				{
+					Host: ingressSpec.Host,
 					IngressRuleValue: networkingv1.IngressRuleValue{
 						HTTP: &networkingv1.HTTPIngressRuleValue{
 							Paths: paths,
@@ -69,13 +94,66 @@ func BuildIngressForHeadService(cluster rayiov1alpha1.RayCluster) (*networkingv1
 		},
 	}
 
-	// Get ingress class name from rayCluster annotations. this is a required field to use ingress.
-	ingressClassName, ok := cluster.Annotations[IngressClassAnnotationKey]
+	if ingressSpec.TLSSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: ingressSpec.TLSSecretName}
+		if ingressSpec.Host != "" {
+			tls.Hosts = []string{ingressSpec.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+
+	// Get ingress class name from the ingress or rayCluster annotations. this is a required field to use ingress.
+	ingressClassName, ok := ingressSpec.Annotations[IngressClassAnnotationKey]
+	if !ok {
+		ingressClassName, ok = cluster.Annotations[IngressClassAnnotationKey]
+	}
 	if !ok {
 		logrus.Warn(fmt.Sprintf("ingress class annotation is not set for cluster %s/%s", cluster.Namespace, cluster.Name))
 	} else {
+		ingress.Annotations[IngressClassAnnotationKey] = ingressClassName
 		ingress.Spec.IngressClassName = &ingressClassName
 	}
 
 	return ingress, nil
 }
+
+// SyncHeadIngress copies the rules, TLS, ingress class, labels and annotations of the desired head ingress
+// into the live one. It returns true if the live ingress was changed.
+func SyncHeadIngress(live *networkingv1.Ingress, desired *networkingv1.Ingress) bool {
+	changed := false
+	if !reflect.DeepEqual(live.Spec.Rules, desired.Spec.Rules) {
+		live.Spec.Rules = desired.Spec.Rules
+		changed = true
+	}
+	if !reflect.DeepEqual(live.Spec.TLS, desired.Spec.TLS) {
+		live.Spec.TLS = desired.Spec.TLS
+		changed = true
+	}
+	// the ingress class may be defaulted by the API server
+	if desired.Spec.IngressClassName != nil && !reflect.DeepEqual(live.Spec.IngressClassName, desired.Spec.IngressClassName) {
+		live.Spec.IngressClassName = desired.Spec.IngressClassName
+		changed = true
+	}
+
+	// labels and annotations set by other controllers are kept
+	for key, value := range desired.Labels {
+		if live.Labels[key] != value {
+			if live.Labels == nil {
+				live.Labels = map[string]string{}
+			}
+			live.Labels[key] = value
+			changed = true
+		}
+	}
+	for key, value := range desired.Annotations {
+		if live.Annotations[key] != value {
+			if live.Annotations == nil {
+				live.Annotations = map[string]string{}
+			}
+			live.Annotations[key] = value
+			changed = true
+		}
+	}
+
+	return changed
+}
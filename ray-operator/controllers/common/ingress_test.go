@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -123,3 +124,68 @@ func TestBuildIngressForHeadService(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildIngressForHeadServiceWithIngressSpec(t *testing.T) {
+	cluster := instanceWithIngressEnabled.DeepCopy()
+	cluster.Annotations["team"] = "ray"
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{{Name: DefaultServePortName, ContainerPort: 9000}}
+	pathType := networkingv1.PathTypePrefix
+	cluster.Spec.HeadGroupSpec.Ingress = &rayiov1alpha1.IngressSpec{
+		Host:          "ray.example.com",
+		PathPrefix:    "/ray/",
+		PathType:      &pathType,
+		TLSSecretName: "ray-tls",
+		Ports:         []rayiov1alpha1.IngressPort{rayiov1alpha1.DashboardIngressPort, rayiov1alpha1.ServeIngressPort},
+		Annotations: map[string]string{
+			"nginx.ingress.kubernetes.io/ssl-redirect": "true",
+		},
+	}
+
+	ingress, err := BuildIngressForHeadService(*cluster)
+	assert.Nil(t, err)
+
+	// cluster annotations are not copied except the ingress class
+	assert.Equal(t, "true", ingress.Annotations["nginx.ingress.kubernetes.io/ssl-redirect"])
+	assert.Equal(t, "nginx", ingress.Annotations[IngressClassAnnotationKey])
+	_, ok := ingress.Annotations["team"]
+	assert.False(t, ok)
+
+	assert.Equal(t, "ray.example.com", ingress.Spec.Rules[0].Host)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"ray.example.com"}, SecretName: "ray-tls"}}, ingress.Spec.TLS)
+
+	paths := ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths
+	assert.Equal(t, 2, len(paths))
+	assert.Equal(t, "/ray", paths[0].Path)
+	assert.Equal(t, int32(DefaultDashboardPort), paths[0].Backend.Service.Port.Number)
+	assert.Equal(t, "/ray/serve", paths[1].Path)
+	assert.Equal(t, int32(9000), paths[1].Backend.Service.Port.Number)
+	assert.Equal(t, pathType, *paths[1].PathType)
+}
+
+func TestBuildIngressForHeadServiceWithoutServicePort(t *testing.T) {
+	cluster := instanceWithIngressEnabled.DeepCopy()
+	// the serve port is not declared on the head container, the head service doesn't expose it
+	cluster.Spec.HeadGroupSpec.Ingress = &rayiov1alpha1.IngressSpec{
+		Ports: []rayiov1alpha1.IngressPort{rayiov1alpha1.DashboardIngressPort, rayiov1alpha1.ServeIngressPort},
+	}
+
+	ingress, err := BuildIngressForHeadService(*cluster)
+	assert.Nil(t, ingress)
+	assert.NotNil(t, err)
+}
+
+func TestSyncHeadIngress(t *testing.T) {
+	cluster := instanceWithIngressEnabled.DeepCopy()
+	live, err := BuildIngressForHeadService(*cluster)
+	assert.Nil(t, err)
+	desired, err := BuildIngressForHeadService(*cluster)
+	assert.Nil(t, err)
+	assert.False(t, SyncHeadIngress(live, desired))
+
+	cluster.Spec.HeadGroupSpec.Ingress = &rayiov1alpha1.IngressSpec{Host: "ray.example.com"}
+	desired, err = BuildIngressForHeadService(*cluster)
+	assert.Nil(t, err)
+	assert.True(t, SyncHeadIngress(live, desired))
+	assert.Equal(t, "ray.example.com", live.Spec.Rules[0].Host)
+	assert.False(t, SyncHeadIngress(live, desired))
+}
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BuildNetworkPolicy builds the NetworkPolicy isolating the pods of the cluster. They accept any traffic
+// from the pods with the same cluster label, traffic to the head ports from the peers of the spec,
+// and traffic to the dashboard from the operator pods of any namespace.
+func BuildNetworkPolicy(cluster rayiov1alpha1.RayCluster) (*networkingv1.NetworkPolicy, error) {
+	spec := cluster.Spec.NetworkPolicy
+	clusterSelector := metav1.LabelSelector{MatchLabels: map[string]string{RayClusterLabelKey: cluster.Name}}
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateNetworkPolicyName(cluster.Name),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{RayClusterLabelKey: cluster.Name},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: clusterSelector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{From: []networkingv1.NetworkPolicyPeer{{PodSelector: clusterSelector.DeepCopy()}}},
+			},
+		},
+	}
+	servicePorts := getServicePorts(cluster)
+	// a rule without peers allows everyone, the head ports stay closed instead
+	if len(spec.From) > 0 {
+		rule, err := buildHeadPortsRule(spec, servicePorts)
+		if err != nil {
+			return nil, err
+		}
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, rule)
+	}
+	// the operator calls the dashboard for the RayJobs, the RayServices and the dashboard idle probe
+	if port, ok := servicePorts[DefaultDashboardName]; ok {
+		networkPolicy.Spec.Ingress = append(networkPolicy.Spec.Ingress, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{newNetworkPolicyPort(port)},
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{},
+				PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{KubeRayOperatorLabelKey: KubeRayOperatorLabelValue}},
+			}},
+		})
+	}
+	return networkPolicy, nil
+}
+
+// buildHeadPortsRule builds the rule opening the head ports of the spec to its peers
+func buildHeadPortsRule(spec *rayiov1alpha1.NetworkPolicySpec, servicePorts map[string]int32) (networkingv1.NetworkPolicyIngressRule, error) {
+	portNames := spec.HeadPorts
+	if len(portNames) == 0 {
+		for name := range servicePorts {
+			portNames = append(portNames, name)
+		}
+		// keep a stable order, the ports come from a map
+		sort.Strings(portNames)
+	}
+	// the rule applies to every pod of the cluster, but only the head serves these ports
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	for _, name := range portNames {
+		port, ok := servicePorts[name]
+		if !ok {
+			return rule, fmt.Errorf("port %s is not a port of the head service", name)
+		}
+		rule.Ports = append(rule.Ports, newNetworkPolicyPort(port))
+	}
+	for _, peer := range spec.From {
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: peer.NamespaceSelector,
+			PodSelector:       peer.PodSelector,
+		})
+	}
+	return rule, nil
+}
+
+// newNetworkPolicyPort sets the protocol the API server defaults to, so that the spec read back compares equal
+func newNetworkPolicyPort(port int32) networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	number := intstr.FromInt(int(port))
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &number}
+}
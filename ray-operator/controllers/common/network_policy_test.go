@@ -0,0 +1,74 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildNetworkPolicy(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.NetworkPolicy = &rayiov1alpha1.NetworkPolicySpec{}
+	networkPolicy, err := BuildNetworkPolicy(*cluster)
+	if err != nil {
+		t.Fatalf("Failed to build the NetworkPolicy: %v", err)
+	}
+	if networkPolicy.Name != "raycluster-sample-network-policy" || networkPolicy.Spec.PodSelector.MatchLabels[RayClusterLabelKey] != cluster.Name {
+		t.Fatalf("Expected the NetworkPolicy of the cluster but got `%v`", networkPolicy)
+	}
+	// without peers only the pods of the cluster, and the operator on the dashboard port, are allowed
+	if len(networkPolicy.Spec.Ingress) != 2 || networkPolicy.Spec.Ingress[0].From[0].PodSelector.MatchLabels[RayClusterLabelKey] != cluster.Name {
+		t.Fatalf("Expected the intra-cluster and the operator rules only but got `%v`", networkPolicy.Spec.Ingress)
+	}
+	operatorRule := networkPolicy.Spec.Ingress[1]
+	if len(operatorRule.Ports) != 1 || operatorRule.Ports[0].Port.IntValue() != DefaultDashboardPort {
+		t.Fatalf("Expected the operator to reach the dashboard port only but got `%v`", operatorRule.Ports)
+	}
+	if from := operatorRule.From; len(from) != 1 || from[0].NamespaceSelector == nil || len(from[0].NamespaceSelector.MatchLabels) != 0 ||
+		from[0].PodSelector.MatchLabels[KubeRayOperatorLabelKey] != KubeRayOperatorLabelValue {
+		t.Fatalf("Expected the operator pods of any namespace but got `%v`", from)
+	}
+
+	// the head ports default to all the ports of the head service
+	peer := rayiov1alpha1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}}
+	cluster.Spec.NetworkPolicy.From = []rayiov1alpha1.NetworkPolicyPeer{peer}
+	networkPolicy, err = BuildNetworkPolicy(*cluster)
+	if err != nil {
+		t.Fatalf("Failed to build the NetworkPolicy: %v", err)
+	}
+	if len(networkPolicy.Spec.Ingress) != 3 {
+		t.Fatalf("Expected `%v` but got `%v`", 3, len(networkPolicy.Spec.Ingress))
+	}
+	rule := networkPolicy.Spec.Ingress[1]
+	var ports []int
+	for _, port := range rule.Ports {
+		// the protocol is set like the API server defaults it, or the NetworkPolicy would be updated on every reconcile
+		if port.Protocol == nil || *port.Protocol != corev1.ProtocolTCP {
+			t.Fatalf("Expected the TCP protocol but got `%v`", port.Protocol)
+		}
+		ports = append(ports, port.Port.IntValue())
+	}
+	if expected := []int{DefaultClientPort, DefaultDashboardPort, DefaultRedisPort}; !reflect.DeepEqual(ports, expected) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, ports)
+	}
+	if len(rule.From) != 1 || rule.From[0].NamespaceSelector.MatchLabels["team"] != "a" || rule.From[0].PodSelector != nil {
+		t.Fatalf("Expected the peers of the spec but got `%v`", rule.From)
+	}
+
+	cluster.Spec.NetworkPolicy.HeadPorts = []string{DefaultDashboardName}
+	networkPolicy, err = BuildNetworkPolicy(*cluster)
+	if err != nil {
+		t.Fatalf("Failed to build the NetworkPolicy: %v", err)
+	}
+	if ports := networkPolicy.Spec.Ingress[1].Ports; len(ports) != 1 || ports[0].Port.IntValue() != DefaultDashboardPort {
+		t.Fatalf("Expected `%v` but got `%v`", DefaultDashboardPort, ports)
+	}
+
+	cluster.Spec.NetworkPolicy.HeadPorts = []string{DefaultServePortName}
+	if _, err := BuildNetworkPolicy(*cluster); err == nil {
+		t.Fatalf("Expected an error for a port missing from the head service")
+	}
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildPodDisruptionBudgets builds the PodDisruptionBudgets of the head and worker groups which set one.
+// They select the pods of a group with the cluster and group labels set by labelPod.
+func BuildPodDisruptionBudgets(cluster rayiov1alpha1.RayCluster) []*policyv1beta1.PodDisruptionBudget {
+	var budgets []*policyv1beta1.PodDisruptionBudget
+	if spec := cluster.Spec.HeadGroupSpec.PodDisruptionBudget; spec != nil {
+		budgets = append(budgets, buildPodDisruptionBudget(cluster, RayHeadGroupName, *spec))
+	}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.PodDisruptionBudget != nil {
+			budgets = append(budgets, buildPodDisruptionBudget(cluster, worker.GroupName, *worker.PodDisruptionBudget))
+		}
+	}
+	return budgets
+}
+
+func buildPodDisruptionBudget(cluster rayiov1alpha1.RayCluster, groupName string, spec rayiov1alpha1.PodDisruptionBudgetSpec) *policyv1beta1.PodDisruptionBudget {
+	labels := map[string]string{
+		RayClusterLabelKey:   cluster.Name,
+		RayNodeGroupLabelKey: groupName,
+	}
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GeneratePodDisruptionBudgetName(cluster.Name, groupName),
+			Namespace: cluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: labels},
+			MinAvailable:   spec.MinAvailable,
+			MaxUnavailable: spec.MaxUnavailable,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestBuildPodDisruptionBudgets(t *testing.T) {
+	cluster := instance.DeepCopy()
+	if budgets := BuildPodDisruptionBudgets(*cluster); len(budgets) != 0 {
+		t.Fatalf("Expected no PodDisruptionBudget but got `%v`", budgets)
+	}
+
+	minAvailable := intstr.FromInt(1)
+	maxUnavailable := intstr.FromString("25%")
+	cluster.Spec.HeadGroupSpec.PodDisruptionBudget = &rayiov1alpha1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable}
+	cluster.Spec.WorkerGroupSpecs[0].PodDisruptionBudget = &rayiov1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+	budgets := BuildPodDisruptionBudgets(*cluster)
+	if len(budgets) != 2 {
+		t.Fatalf("Expected `%v` but got `%v`", 2, len(budgets))
+	}
+
+	head := budgets[0]
+	if head.Name != "raycluster-sample-headgroup-pdb" || head.Namespace != cluster.Namespace {
+		t.Fatalf("Expected `%v` but got `%v`", "raycluster-sample-headgroup-pdb", head.Name)
+	}
+	if head.Spec.MinAvailable.IntValue() != 1 || head.Spec.MaxUnavailable != nil {
+		t.Fatalf("Expected minAvailable 1 but got `%v`", head.Spec)
+	}
+	if head.Spec.Selector.MatchLabels[RayClusterLabelKey] != cluster.Name || head.Spec.Selector.MatchLabels[RayNodeGroupLabelKey] != RayHeadGroupName {
+		t.Fatalf("Expected the head group selector but got `%v`", head.Spec.Selector.MatchLabels)
+	}
+
+	worker := budgets[1]
+	groupName := cluster.Spec.WorkerGroupSpecs[0].GroupName
+	if worker.Spec.MaxUnavailable.String() != "25%" || worker.Spec.Selector.MatchLabels[RayNodeGroupLabelKey] != groupName {
+		t.Fatalf("Expected the %s group budget but got `%v`", groupName, worker.Spec)
+	}
+
+	// the selectors match the pods of the group
+	pod := BuildPod(DefaultWorkerPodTemplate(*cluster, cluster.Spec.WorkerGroupSpecs[0], "worker", "svc"),
+		rayiov1alpha1.WorkerNode, cluster.Spec.WorkerGroupSpecs[0].RayStartParams, "svc", nil)
+	for key, value := range worker.Spec.Selector.MatchLabels {
+		if pod.Labels[key] != value {
+			t.Fatalf("Expected label %s=%s on the worker pod but got `%v`", key, value, pod.Labels)
+		}
+	}
+}
@@ -2,14 +2,19 @@ package common
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"sort"
 	"strconv"
 	"strings"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/batchscheduler"
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/rand"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -20,6 +25,12 @@ import (
 const (
 	SharedMemoryVolumeName      = "shared-mem"
 	SharedMemoryVolumeMountPath = "/dev/shm"
+
+	// the ray logs are shared between the ray and the autoscaler containers of the head
+	RayLogVolumeName      = "ray-logs"
+	RayLogVolumeMountPath = "/tmp/ray"
+
+	AutoscalerContainerName = "autoscaler"
 )
 
 var (
@@ -28,7 +39,8 @@ var (
 
 // DefaultHeadPodTemplate sets the config values
 func DefaultHeadPodTemplate(instance rayiov1alpha1.RayCluster, headSpec rayiov1alpha1.HeadGroupSpec, podName string, svcName string) v1.PodTemplateSpec {
-	podTemplate := headSpec.Template
+	// copy the template so that the defaults below don't leak into the RayCluster spec
+	podTemplate := *headSpec.Template.DeepCopy()
 	podTemplate.GenerateName = podName
 	if podTemplate.ObjectMeta.Namespace == "" {
 		podTemplate.ObjectMeta.Namespace = instance.Namespace
@@ -38,14 +50,159 @@ func DefaultHeadPodTemplate(instance rayiov1alpha1.RayCluster, headSpec rayiov1a
 	if podTemplate.Labels == nil {
 		podTemplate.Labels = make(map[string]string)
 	}
-	podTemplate.Labels = labelPod(rayiov1alpha1.HeadNode, instance.Name, "headgroup", instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
-	headSpec.RayStartParams = setMissingRayStartParams(headSpec.RayStartParams, rayiov1alpha1.HeadNode, svcName)
+	podTemplate.Labels = labelPod(rayiov1alpha1.HeadNode, instance.Name, RayHeadGroupName, instance.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
+	if len(podTemplate.Spec.Containers) == 0 {
+		// there is no ray container to complete, the controller doesn't create such pods
+		return podTemplate
+	}
+	headSpec.RayStartParams = setMissingRayStartParams(headSpec.RayStartParams, rayiov1alpha1.HeadNode, svcName, getServicePorts(instance)[DefaultRedisPortName],
+		podTemplate.Spec.Containers[utils.FindRayContainerIndex(podTemplate.Spec)])
+	if instance.Spec.GracefulShutdown != nil {
+		setHeadPreStopHook(&podTemplate.Spec, *instance.Spec.GracefulShutdown)
+	}
+	setHeadContainerPorts(&podTemplate.Spec, instance)
+	setRedisPasswordEnv(&podTemplate.Spec, instance)
+	addToPodGroup(&podTemplate, instance)
+	if IsAutoscalingEnabled(instance) {
+		if podTemplate.Spec.ServiceAccountName == "" {
+			podTemplate.Spec.ServiceAccountName = GetAutoscalerServiceAccountName(instance)
+		}
+		addAutoscalerContainer(&podTemplate.Spec, instance.Spec.AutoscalerOptions, headSpec.RayStartParams)
+	}
 	return podTemplate
 }
 
+// IsAutoscalingEnabled returns true if the autoscaler must be injected in the head pod
+func IsAutoscalingEnabled(instance rayiov1alpha1.RayCluster) bool {
+	return instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling
+}
+
+// addAutoscalerContainer appends the autoscaler container to the head pod and shares the ray logs with it.
+// The autoscaler reads the idle timeout and upscaling mode from the RayCluster.
+func addAutoscalerContainer(podSpec *v1.PodSpec, options *rayiov1alpha1.AutoscalerOptions, rayStartParams map[string]string) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	for _, container := range podSpec.Containers {
+		if container.Name == AutoscalerContainerName {
+			// the user provided its own autoscaler
+			return
+		}
+	}
+	index := utils.FindRayContainerIndex(*podSpec)
+	rayContainer := &podSpec.Containers[index]
+
+	args := []string{"kuberay-autoscaler", "--cluster-name", "$(RAY_CLUSTER_NAME)", "--cluster-namespace", "$(RAY_CLUSTER_NAMESPACE)"}
+	container := v1.Container{
+		Name:            AutoscalerContainerName,
+		Image:           rayContainer.Image,
+		ImagePullPolicy: rayContainer.ImagePullPolicy,
+		Command:         []string{"ray"},
+		Args:            args,
+		Env: []v1.EnvVar{
+			{
+				Name: "RAY_CLUSTER_NAME",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.labels['%s']", RayClusterLabelKey)},
+				},
+			},
+			{
+				Name: "RAY_CLUSTER_NAMESPACE",
+				ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+				},
+			},
+		},
+		Resources: v1.ResourceRequirements{
+			Limits: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("500m"),
+				v1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("500m"),
+				v1.ResourceMemory: resource.MustParse("512Mi"),
+			},
+		},
+	}
+	// the password is passed through the env so that it doesn't show in the args
+	if env := findEnvVar(rayContainer.Env, REDIS_PASSWORD); env != nil {
+		container.Env = append(container.Env, *env)
+	} else if password, ok := rayStartParams["redis-password"]; ok {
+		container.Env = append(container.Env, v1.EnvVar{Name: REDIS_PASSWORD, Value: password})
+	}
+	if findEnvVar(container.Env, REDIS_PASSWORD) != nil {
+		container.Args = append(container.Args, "--redis-password", fmt.Sprintf("$(%s)", REDIS_PASSWORD))
+	}
+	if options != nil {
+		if options.Image != "" {
+			container.Image = options.Image
+		}
+		if options.ImagePullPolicy != "" {
+			container.ImagePullPolicy = options.ImagePullPolicy
+		}
+		if options.Resources != nil {
+			container.Resources = *options.Resources
+		}
+		if options.IdleTimeoutSeconds != nil {
+			container.Env = append(container.Env, v1.EnvVar{Name: AUTOSCALER_IDLE_TIMEOUT_SECONDS, Value: strconv.Itoa(int(*options.IdleTimeoutSeconds))})
+		}
+		if options.UpscalingMode != "" {
+			container.Env = append(container.Env, v1.EnvVar{Name: AUTOSCALER_UPSCALING_MODE, Value: string(options.UpscalingMode)})
+		}
+	}
+
+	logVolumeName := RayLogVolumeName
+	if mount := findVolumeMount(rayContainer, RayLogVolumeMountPath); mount != nil {
+		logVolumeName = mount.Name
+	} else {
+		podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+			Name:         RayLogVolumeName,
+			VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}},
+		})
+		rayContainer.VolumeMounts = append(rayContainer.VolumeMounts, v1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath})
+	}
+	container.VolumeMounts = []v1.VolumeMount{{Name: logVolumeName, MountPath: RayLogVolumeMountPath}}
+
+	podSpec.Containers = append(podSpec.Containers, container)
+}
+
+func findVolumeMount(container *v1.Container, mountPath string) *v1.VolumeMount {
+	for index := range container.VolumeMounts {
+		if container.VolumeMounts[index].MountPath == mountPath {
+			return &container.VolumeMounts[index]
+		}
+	}
+	return nil
+}
+
+// setHeadPreStopHook makes the kubelet drain the head before stopping the ray container, unless the user set a hook
+func setHeadPreStopHook(podSpec *v1.PodSpec, gracefulShutdown rayiov1alpha1.GracefulShutdownSpec) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	index := utils.FindRayContainerIndex(*podSpec)
+	container := &podSpec.Containers[index]
+	if container.Lifecycle == nil {
+		container.Lifecycle = &v1.Lifecycle{}
+	}
+	if container.Lifecycle.PreStop == nil {
+		command := gracefulShutdown.PreStopCommand
+		if len(command) == 0 {
+			command = []string{"/bin/bash", "-c", DefaultPreStopCommand}
+		}
+		container.Lifecycle.PreStop = &v1.Handler{
+			Exec: &v1.ExecAction{Command: command},
+		}
+	}
+	if gracefulShutdown.TerminationGracePeriodSeconds != nil {
+		podSpec.TerminationGracePeriodSeconds = gracefulShutdown.TerminationGracePeriodSeconds
+	}
+}
+
 // DefaultWorkerPodTemplate sets the config values
 func DefaultWorkerPodTemplate(instance rayiov1alpha1.RayCluster, workerSpec rayiov1alpha1.WorkerGroupSpec, podName string, svcName string) v1.PodTemplateSpec {
-	podTemplate := workerSpec.Template
+	// copy the template so that the defaults below don't leak into the RayCluster spec
+	podTemplate := *workerSpec.Template.DeepCopy()
 	podTemplate.GenerateName = podName
 	if podTemplate.ObjectMeta.Namespace == "" {
 		podTemplate.ObjectMeta.Namespace = instance.Namespace
@@ -56,13 +213,114 @@ func DefaultWorkerPodTemplate(instance rayiov1alpha1.RayCluster, workerSpec rayi
 		podTemplate.Labels = make(map[string]string)
 	}
 	podTemplate.Labels = labelPod(rayiov1alpha1.WorkerNode, instance.Name, workerSpec.GroupName, workerSpec.Template.ObjectMeta.Labels)
-	workerSpec.RayStartParams = setMissingRayStartParams(workerSpec.RayStartParams, rayiov1alpha1.WorkerNode, svcName)
+	if len(podTemplate.Spec.Containers) == 0 {
+		// there is no ray container to complete, the controller doesn't create such pods
+		return podTemplate
+	}
+	// the workers connect to the port of the head, set by its own ray start params
+	headPort := getServicePorts(instance)[DefaultRedisPortName]
+	workerSpec.RayStartParams = setMissingRayStartParams(workerSpec.RayStartParams, rayiov1alpha1.WorkerNode, svcName, headPort,
+		podTemplate.Spec.Containers[utils.FindRayContainerIndex(podTemplate.Spec)])
+	if container := &podTemplate.Spec.Containers[utils.FindRayContainerIndex(podTemplate.Spec)]; !envVarExists(RAY_PORT, container.Env) {
+		container.Env = append(container.Env, v1.EnvVar{Name: RAY_PORT, Value: strconv.Itoa(int(headPort))})
+	}
+	setRedisPasswordEnv(&podTemplate.Spec, instance)
+	addToPodGroup(&podTemplate, instance)
 
 	return podTemplate
 }
 
+// setHeadContainerPorts makes sure the ray container of the head declares the ports of the head service
+func setHeadContainerPorts(podSpec *v1.PodSpec, cluster rayiov1alpha1.RayCluster) {
+	if len(podSpec.Containers) == 0 {
+		return
+	}
+	container := &podSpec.Containers[utils.FindRayContainerIndex(*podSpec)]
+	servicePorts := getServicePorts(cluster)
+	names := make([]string, 0, len(servicePorts))
+	for name := range servicePorts {
+		names = append(names, name)
+	}
+	// keep a stable order, the ports come from a map
+	sort.Strings(names)
+
+	for _, name := range names {
+		found := false
+		for index := range container.Ports {
+			port := &container.Ports[index]
+			if port.Name == name {
+				port.ContainerPort = servicePorts[name]
+				found = true
+			} else if port.ContainerPort == servicePorts[name] && (port.Protocol == "" || port.Protocol == v1.ProtocolTCP) {
+				// the port is already declared under another name
+				found = true
+			}
+		}
+		if !found {
+			container.Ports = append(container.Ports, v1.ContainerPort{Name: name, ContainerPort: servicePorts[name]})
+		}
+	}
+}
+
+// addToPodGroup hands the pods of a cluster with a batch scheduler over to that scheduler and its PodGroup
+func addToPodGroup(podTemplate *v1.PodTemplateSpec, instance rayiov1alpha1.RayCluster) {
+	scheduler, err := batchscheduler.GetScheduler(&instance)
+	if err != nil {
+		log.Error(err, "Failed to add the pod to the PodGroup of the cluster", "cluster name", instance.Name)
+		return
+	}
+	if scheduler != nil {
+		scheduler.AddToPodGroup(&instance, podTemplate)
+	}
+}
+
+// GeneratePodTemplateHash returns a hash of the pod template and ray start params of a group.
+// Pods annotated with a different hash were built from an outdated spec.
+func GeneratePodTemplateHash(template v1.PodTemplateSpec, rayStartParams map[string]string) (string, error) {
+	return hashObject(struct {
+		Template       v1.PodTemplateSpec `json:"template"`
+		RayStartParams map[string]string  `json:"rayStartParams"`
+	}{template, rayStartParams})
+}
+
+// GenerateHeadPodTemplateHash returns a hash of the head group like GeneratePodTemplateHash, which also covers the
+// cluster fields changing the head pod: the autoscaler, the graceful shutdown and the redis password.
+// The fields are left out of the hash when they are not set, so that it matches the hash of the head group alone.
+func GenerateHeadPodTemplateHash(instance rayiov1alpha1.RayCluster) (string, error) {
+	var autoscalerOptions *rayiov1alpha1.AutoscalerOptions
+	enableAutoscaling := instance.Spec.EnableInTreeAutoscaling != nil && *instance.Spec.EnableInTreeAutoscaling
+	if enableAutoscaling {
+		autoscalerOptions = instance.Spec.AutoscalerOptions
+	}
+	return hashObject(struct {
+		Template                v1.PodTemplateSpec                  `json:"template"`
+		RayStartParams          map[string]string                   `json:"rayStartParams"`
+		EnableInTreeAutoscaling bool                                `json:"enableInTreeAutoscaling,omitempty"`
+		AutoscalerOptions       *rayiov1alpha1.AutoscalerOptions    `json:"autoscalerOptions,omitempty"`
+		GracefulShutdown        *rayiov1alpha1.GracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+		RedisPassword           *rayiov1alpha1.RedisPasswordSpec    `json:"redisPassword,omitempty"`
+	}{
+		instance.Spec.HeadGroupSpec.Template,
+		instance.Spec.HeadGroupSpec.RayStartParams,
+		enableAutoscaling,
+		autoscalerOptions,
+		instance.Spec.GracefulShutdown,
+		instance.Spec.RedisPassword,
+	})
+}
+
+func hashObject(object interface{}) (string, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())), nil
+}
+
 // BuildPod a pod config
-func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayiov1alpha1.RayNodeType, rayStartParams map[string]string, svcName string) (aPod v1.Pod) {
+func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayiov1alpha1.RayNodeType, rayStartParams map[string]string, svcName string, enableRayAutoscaler *bool) (aPod v1.Pod) {
 	pod := v1.Pod{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -79,6 +337,23 @@ func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayiov1alpha1.RayN
 		cleanupInvalidVolumeMounts(&pod.Spec.InitContainers[index], &pod)
 	}
 
+	// the params are copied so that the changes below don't leak into the RayCluster spec
+	params := map[string]string{}
+	for key, value := range rayStartParams {
+		params[key] = value
+	}
+	if rayNodeType == rayiov1alpha1.HeadNode && enableRayAutoscaler != nil && *enableRayAutoscaler {
+		// the autoscaler container replaces the monitor process of the head
+		if _, ok := params["no-monitor"]; !ok {
+			params["no-monitor"] = "true"
+		}
+	}
+	if env := findEnvVar(pod.Spec.Containers[index].Env, REDIS_PASSWORD); env != nil && env.ValueFrom != nil {
+		// the password is read from the secret by the shell running ray start, so it doesn't show in the pod spec
+		params["redis-password"] = "$" + REDIS_PASSWORD
+	}
+	rayStartParams = params
+
 	var cmd, args string
 	if len(pod.Spec.Containers[index].Command) > 0 {
 		cmd = convertCmdToString(pod.Spec.Containers[index].Command)
@@ -87,7 +362,11 @@ func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayiov1alpha1.RayN
 		cmd += convertCmdToString(pod.Spec.Containers[index].Args)
 	}
 	if !strings.Contains(cmd, "ray start") {
-		cont := concatenateContainerCommand(rayNodeType, rayStartParams)
+		if _, ok := rayStartParams["block"]; !ok && cmd == "" {
+			// ray start stays in the foreground so that the container fails when the ray processes die
+			rayStartParams["block"] = "true"
+		}
+		cont := BuildRayStartCommand(rayNodeType, rayStartParams)
 		// replacing the old command
 		pod.Spec.Containers[index].Command = []string{"/bin/bash", "-c", "--"}
 		if cmd != "" {
@@ -97,7 +376,8 @@ func BuildPod(podTemplateSpec v1.PodTemplateSpec, rayNodeType rayiov1alpha1.RayN
 		}
 
 		if !isRayStartWithBlock(rayStartParams) {
-			// sleep infinity is used to keep the pod `running` after the last command exits, and not go into `completed` state
+			// ray start returns to run the command of the container or because block was disabled,
+			// sleep infinity keeps the pod `running` after the last command exits, and not go into `completed` state
 			args = args + " && sleep infinity"
 		}
 
@@ -230,6 +510,15 @@ func setContainerEnvVars(container *v1.Container, rayNodeType rayiov1alpha1.RayN
 	}
 }
 
+func findEnvVar(envVars []v1.EnvVar, envName string) *v1.EnvVar {
+	for index := range envVars {
+		if envVars[index].Name == envName {
+			return &envVars[index]
+		}
+	}
+	return nil
+}
+
 func envVarExists(envName string, envVars []v1.EnvVar) bool {
 	if len(envVars) == 0 {
 		return false
@@ -243,13 +532,15 @@ func envVarExists(envName string, envVars []v1.EnvVar) bool {
 	return false
 }
 
-//TODO auto complete params
-func setMissingRayStartParams(rayStartParams map[string]string, nodeType rayiov1alpha1.RayNodeType, svcName string) (completeStartParams map[string]string) {
+// setMissingRayStartParams completes the params set by the user with the address of the head for the workers,
+// and the resources of the ray container
+func setMissingRayStartParams(rayStartParams map[string]string, nodeType rayiov1alpha1.RayNodeType, svcName string, headPort int32, container v1.Container) (completeStartParams map[string]string) {
+	setResourceRayStartParams(rayStartParams, container)
 	if nodeType == rayiov1alpha1.WorkerNode {
 		if _, ok := rayStartParams["address"]; !ok {
 			address := svcName
 			if _, okPort := rayStartParams["port"]; !okPort {
-				address = fmt.Sprintf("%s:%s", address, "6379")
+				address = fmt.Sprintf("%s:%d", address, headPort)
 			} else {
 				address = fmt.Sprintf("%s:%s", address, rayStartParams["port"])
 			}
@@ -259,29 +550,64 @@ func setMissingRayStartParams(rayStartParams map[string]string, nodeType rayiov1
 	return rayStartParams
 }
 
-// concatenateContainerCommand with ray start
-func concatenateContainerCommand(nodeType rayiov1alpha1.RayNodeType, rayStartParams map[string]string) (fullCmd string) {
-	switch nodeType {
-	case rayiov1alpha1.HeadNode:
-		return fmt.Sprintf("ulimit -n 65536; ray start --head %s", convertParamMap(rayStartParams))
-	case rayiov1alpha1.WorkerNode:
-		return fmt.Sprintf("ulimit -n 65536; ray start %s", convertParamMap(rayStartParams))
-	default:
-		log.Error(fmt.Errorf("missing node type"), "a node must be either head or worker")
-	}
-	return ""
-}
+// setResourceRayStartParams fills in the num-cpus, num-gpus, memory and object-store-memory params missing from
+// rayStartParams from the limits of the ray container, or its requests. Otherwise ray sees the resources of the host.
+func setResourceRayStartParams(rayStartParams map[string]string, container v1.Container) {
+	if _, ok := rayStartParams["num-cpus"]; !ok {
+		if cpu := findResourceLimitOrReq(container, v1.ResourceCPU); cpu != nil {
+			// ray only accepts whole cpus, fractions are rounded up
+			rayStartParams["num-cpus"] = strconv.FormatInt(cpu.Value(), 10)
+		}
+	}
 
-func convertParamMap(rayStartParams map[string]string) (s string) {
-	flags := new(bytes.Buffer)
-	for k, v := range rayStartParams {
-		if strings.ToLower(v) == "true" {
-			fmt.Fprintf(flags, " --%s ", k)
-		} else {
-			fmt.Fprintf(flags, " --%s=%s ", k, v)
+	if _, ok := rayStartParams["num-gpus"]; !ok {
+		var gpus int64
+		for name, quantity := range container.Resources.Limits {
+			if isGPUResource(name) {
+				gpus += quantity.Value()
+			}
+		}
+		// extended resources can't be overcommitted, requests only matter when the limits are not set
+		for name, quantity := range container.Resources.Requests {
+			if _, ok := container.Resources.Limits[name]; !ok && isGPUResource(name) {
+				gpus += quantity.Value()
+			}
+		}
+		if gpus > 0 {
+			rayStartParams["num-gpus"] = strconv.FormatInt(gpus, 10)
+		}
+	}
+
+	memory := findResourceLimitOrReq(container, v1.ResourceMemory)
+	if memory == nil {
+		return
+	}
+	if _, ok := rayStartParams["object-store-memory"]; !ok {
+		rayStartParams["object-store-memory"] = strconv.FormatInt(memory.Value()*DefaultObjectStoreMemoryPercent/100, 10)
+	}
+	if _, ok := rayStartParams["memory"]; !ok {
+		// the object store is not part of the memory available to the tasks and actors
+		objectStoreMemory, err := strconv.ParseInt(rayStartParams["object-store-memory"], 10, 64)
+		if err == nil && memory.Value() > objectStoreMemory {
+			rayStartParams["memory"] = strconv.FormatInt(memory.Value()-objectStoreMemory, 10)
 		}
 	}
-	return flags.String()
+}
+
+// isGPUResource returns true for the resources of the GPU device plugins, e.g. nvidia.com/gpu or amd.com/gpu
+func isGPUResource(name v1.ResourceName) bool {
+	return strings.HasSuffix(string(name), "/gpu")
+}
+
+// findResourceLimitOrReq returns the limit of a resource of the container, or its request if it has no limit
+func findResourceLimitOrReq(container v1.Container, name v1.ResourceName) *resource.Quantity {
+	if q, ok := container.Resources.Limits[name]; ok && !q.IsZero() {
+		return &q
+	}
+	if q, ok := container.Resources.Requests[name]; ok && !q.IsZero() {
+		return &q
+	}
+	return nil
 }
 
 // addEmptyDir add an emptyDir to the shared memory mount point /dev/shm
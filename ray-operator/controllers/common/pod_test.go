@@ -11,6 +11,7 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -109,7 +110,7 @@ func TestBuildPod(t *testing.T) {
 	podName := strings.ToLower(instance.Name + DashSymbol + string(rayiov1alpha1.HeadNode) + DashSymbol + utils.FormatInt32(0))
 	svcName := utils.GenerateServiceName(instance.Name)
 	podTemplateSpec := DefaultHeadPodTemplate(*instance, instance.Spec.HeadGroupSpec, podName, svcName)
-	pod := BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, svcName)
+	pod := BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, svcName, nil)
 
 	actualResult := pod.Labels[RayClusterLabelKey]
 	expectedResult := instance.Name
@@ -131,7 +132,7 @@ func TestBuildPod(t *testing.T) {
 	worker := instance.Spec.WorkerGroupSpecs[0]
 	podName = instance.Name + DashSymbol + string(rayiov1alpha1.WorkerNode) + DashSymbol + worker.GroupName + DashSymbol + utils.FormatInt32(0)
 	podTemplateSpec = DefaultWorkerPodTemplate(*instance, worker, podName, svcName)
-	pod = BuildPod(podTemplateSpec, rayiov1alpha1.WorkerNode, worker.RayStartParams, svcName)
+	pod = BuildPod(podTemplateSpec, rayiov1alpha1.WorkerNode, worker.RayStartParams, svcName, nil)
 
 	expectedResult = fmt.Sprintf("%s:6379", svcName)
 	actualResult = instance.Spec.WorkerGroupSpecs[0].RayStartParams["address"]
@@ -146,6 +147,201 @@ func TestBuildPod(t *testing.T) {
 	}
 }
 
+func TestDefaultHeadPodTemplateWithGracefulShutdown(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.GracefulShutdown = &rayiov1alpha1.GracefulShutdownSpec{
+		TerminationGracePeriodSeconds: pointer.Int64Ptr(120),
+	}
+	svcName := utils.GenerateServiceName(cluster.Name)
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName)
+
+	preStop := podTemplateSpec.Spec.Containers[0].Lifecycle.PreStop
+	expectedCommand := []string{"/bin/bash", "-c", DefaultPreStopCommand}
+	if !reflect.DeepEqual(expectedCommand, preStop.Exec.Command) {
+		t.Fatalf("Expected `%v` but got `%v`", expectedCommand, preStop.Exec.Command)
+	}
+	if *podTemplateSpec.Spec.TerminationGracePeriodSeconds != 120 {
+		t.Fatalf("Expected `%v` but got `%v`", 120, *podTemplateSpec.Spec.TerminationGracePeriodSeconds)
+	}
+	// the cluster spec must not be modified
+	if cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Lifecycle != nil {
+		t.Fatalf("Expected head template lifecycle to be nil but got `%v`", cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Lifecycle)
+	}
+}
+
+func TestDefaultPodTemplatesWithoutContainer(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers = nil
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers = nil
+	svcName := utils.GenerateServiceName(cluster.Name)
+
+	// the templates are left without container instead of panicking
+	if podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName); len(podTemplateSpec.Spec.Containers) != 0 {
+		t.Fatalf("Expected no container but got `%v`", podTemplateSpec.Spec.Containers)
+	}
+	if podTemplateSpec := DefaultWorkerPodTemplate(*cluster, cluster.Spec.WorkerGroupSpecs[0], "raycluster-sample-worker-", svcName); len(podTemplateSpec.Spec.Containers) != 0 {
+		t.Fatalf("Expected no container but got `%v`", podTemplateSpec.Spec.Containers)
+	}
+}
+
+func TestDefaultHeadPodTemplateWithAutoscaler(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = pointer.BoolPtr(true)
+	cluster.Spec.AutoscalerOptions = &rayiov1alpha1.AutoscalerOptions{
+		Image:              "rayproject/ray:nightly",
+		IdleTimeoutSeconds: pointer.Int32Ptr(120),
+		UpscalingMode:      rayiov1alpha1.ConservativeUpscalingMode,
+	}
+	svcName := utils.GenerateServiceName(cluster.Name)
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName)
+
+	if podTemplateSpec.Spec.ServiceAccountName != cluster.Name {
+		t.Fatalf("Expected `%v` but got `%v`", cluster.Name, podTemplateSpec.Spec.ServiceAccountName)
+	}
+	if len(podTemplateSpec.Spec.Containers) != 2 {
+		t.Fatalf("Expected `%v` but got `%v`", 2, len(podTemplateSpec.Spec.Containers))
+	}
+	autoscaler := podTemplateSpec.Spec.Containers[1]
+	if autoscaler.Name != AutoscalerContainerName {
+		t.Fatalf("Expected `%v` but got `%v`", AutoscalerContainerName, autoscaler.Name)
+	}
+	if autoscaler.Image != "rayproject/ray:nightly" {
+		t.Fatalf("Expected `%v` but got `%v`", "rayproject/ray:nightly", autoscaler.Image)
+	}
+	if env := findEnvVar(autoscaler.Env, AUTOSCALER_IDLE_TIMEOUT_SECONDS); env == nil || env.Value != "120" {
+		t.Fatalf("Expected `%v` but got `%v`", "120", env)
+	}
+	if env := findEnvVar(autoscaler.Env, AUTOSCALER_UPSCALING_MODE); env == nil || env.Value != "Conservative" {
+		t.Fatalf("Expected `%v` but got `%v`", "Conservative", env)
+	}
+	// the ray logs are shared with the ray container
+	rayMount := findVolumeMount(&podTemplateSpec.Spec.Containers[0], RayLogVolumeMountPath)
+	if rayMount == nil || !reflect.DeepEqual(autoscaler.VolumeMounts, []corev1.VolumeMount{*rayMount}) {
+		t.Fatalf("Expected `%v` but got `%v`", rayMount, autoscaler.VolumeMounts)
+	}
+	// the cluster spec must not be modified
+	if len(cluster.Spec.HeadGroupSpec.Template.Spec.Containers) != 1 {
+		t.Fatalf("Expected `%v` but got `%v`", 1, len(cluster.Spec.HeadGroupSpec.Template.Spec.Containers))
+	}
+
+	pod := BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, svcName, cluster.Spec.EnableInTreeAutoscaling)
+	if !strings.Contains(pod.Spec.Containers[0].Args[0], "--no-monitor ") {
+		t.Fatalf("Expected `%v` in `%v`", "--no-monitor", pod.Spec.Containers[0].Args[0])
+	}
+	if _, ok := cluster.Spec.HeadGroupSpec.RayStartParams["no-monitor"]; ok {
+		t.Fatalf("Expected the ray start params of the cluster not to be modified")
+	}
+}
+
+func TestBuildPodWithRedisPasswordSecret(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.RedisPassword = &rayiov1alpha1.RedisPasswordSpec{SecretName: "redis", SecretKey: "key"}
+	cluster.Spec.EnableInTreeAutoscaling = pointer.BoolPtr(true)
+	svcName := utils.GenerateServiceName(cluster.Name)
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName)
+	pod := BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, svcName, cluster.Spec.EnableInTreeAutoscaling)
+
+	expectedEnv := corev1.EnvVar{
+		Name: REDIS_PASSWORD,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis"}, Key: "key"},
+		},
+	}
+	for _, container := range pod.Spec.Containers {
+		if env := findEnvVar(container.Env, REDIS_PASSWORD); env == nil || !reflect.DeepEqual(*env, expectedEnv) {
+			t.Fatalf("Expected `%v` but got `%v`", expectedEnv, env)
+		}
+		for _, arg := range container.Args {
+			if strings.Contains(arg, "LetMeInRay") {
+				t.Fatalf("Expected the password not to show in `%v`", arg)
+			}
+		}
+	}
+	// the password is expanded by the shell running ray start
+	if !strings.Contains(pod.Spec.Containers[0].Args[0], `--redis-password="$REDIS_PASSWORD"`) {
+		t.Fatalf("Expected `%v` in `%v`", `--redis-password="$REDIS_PASSWORD"`, pod.Spec.Containers[0].Args[0])
+	}
+	expectedArgs := []string{"--redis-password", "$(REDIS_PASSWORD)"}
+	autoscalerArgs := pod.Spec.Containers[1].Args
+	if !reflect.DeepEqual(expectedArgs, autoscalerArgs[len(autoscalerArgs)-2:]) {
+		t.Fatalf("Expected `%v` but got `%v`", expectedArgs, autoscalerArgs)
+	}
+
+	// the worker template of the cluster must not be modified
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podTemplateSpec = DefaultWorkerPodTemplate(*cluster, worker, "raycluster-sample-worker-", svcName)
+	if env := findEnvVar(podTemplateSpec.Spec.Containers[0].Env, REDIS_PASSWORD); env == nil || !reflect.DeepEqual(*env, expectedEnv) {
+		t.Fatalf("Expected `%v` but got `%v`", expectedEnv, env)
+	}
+	if findEnvVar(worker.Template.Spec.Containers[0].Env, REDIS_PASSWORD) != nil {
+		t.Fatalf("Expected the worker template not to be modified")
+	}
+}
+
+func TestGeneratePodTemplateHash(t *testing.T) {
+	worker := instance.Spec.WorkerGroupSpecs[0].DeepCopy()
+	hash, err := GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	sameHash, _ := GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+	if hash != sameHash {
+		t.Fatalf("Expected `%v` but got `%v`", hash, sameHash)
+	}
+
+	worker.Template.Spec.Containers[0].Image = "rayproject/ray:nightly"
+	newHash, _ := GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+	if hash == newHash {
+		t.Fatalf("Expected the hash to change when the image changes")
+	}
+
+	worker = instance.Spec.WorkerGroupSpecs[0].DeepCopy()
+	worker.RayStartParams["num-cpus"] = "2"
+	newHash, _ = GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+	if hash == newHash {
+		t.Fatalf("Expected the hash to change when the ray start params change")
+	}
+}
+
+func TestGenerateHeadPodTemplateHash(t *testing.T) {
+	cluster := instance.DeepCopy()
+	hash, err := GenerateHeadPodTemplateHash(*cluster)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	// the cluster fields that are not set don't change the hash of the head group
+	groupHash, _ := GeneratePodTemplateHash(cluster.Spec.HeadGroupSpec.Template, cluster.Spec.HeadGroupSpec.RayStartParams)
+	if hash != groupHash {
+		t.Fatalf("Expected `%v` but got `%v`", groupHash, hash)
+	}
+
+	tests := map[string]func(cluster *rayiov1alpha1.RayCluster){
+		"autoscaling enabled": func(cluster *rayiov1alpha1.RayCluster) {
+			cluster.Spec.EnableInTreeAutoscaling = pointer.BoolPtr(true)
+		},
+		"graceful shutdown": func(cluster *rayiov1alpha1.RayCluster) {
+			cluster.Spec.GracefulShutdown = &rayiov1alpha1.GracefulShutdownSpec{}
+		},
+		"redis password secret": func(cluster *rayiov1alpha1.RayCluster) {
+			cluster.Spec.RedisPassword = &rayiov1alpha1.RedisPasswordSpec{SecretName: "redis"}
+		},
+	}
+	for name, mutate := range tests {
+		cluster := instance.DeepCopy()
+		mutate(cluster)
+		if newHash, _ := GenerateHeadPodTemplateHash(*cluster); newHash == hash {
+			t.Fatalf("%s: Expected the hash to change", name)
+		}
+	}
+
+	// the autoscaler options only matter when autoscaling is enabled
+	cluster.Spec.EnableInTreeAutoscaling = pointer.BoolPtr(false)
+	cluster.Spec.AutoscalerOptions = &rayiov1alpha1.AutoscalerOptions{Image: "rayproject/ray:nightly"}
+	if newHash, _ := GenerateHeadPodTemplateHash(*cluster); newHash != hash {
+		t.Fatalf("Expected `%v` but got `%v`", hash, newHash)
+	}
+}
+
 func splitAndSort(s string) []string {
 	strs := strings.Split(s, " ")
 	result := make([]string, 0, len(strs))
@@ -157,3 +353,113 @@ func splitAndSort(s string) []string {
 	sort.Strings(result)
 	return result
 }
+
+func TestDefaultPodTemplatesWithPortParams(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{"port": "6380", "dashboard-port": "8266"}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultDashboardName, ContainerPort: 8265},
+		{Name: "gcs", ContainerPort: 6380},
+	}
+	svcName := utils.GenerateServiceName(cluster.Name)
+
+	// the ports of the params are declared on the head container, disagreeing ones are fixed
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName)
+	expectedPorts := []corev1.ContainerPort{
+		{Name: DefaultDashboardName, ContainerPort: 8266},
+		{Name: "gcs", ContainerPort: 6380},
+		{Name: DefaultClientPortName, ContainerPort: DefaultClientPort},
+	}
+	if ports := podTemplateSpec.Spec.Containers[0].Ports; !reflect.DeepEqual(expectedPorts, ports) {
+		t.Fatalf("Expected `%v` but got `%v`", expectedPorts, ports)
+	}
+	if ports := cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports; ports[0].ContainerPort != 8265 {
+		t.Fatalf("Expected the RayCluster spec to be unchanged but got `%v`", ports)
+	}
+
+	// the workers connect to the port of the head
+	worker := *cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	worker.RayStartParams = map[string]string{}
+	podTemplateSpec = DefaultWorkerPodTemplate(*cluster, worker, "raycluster-sample-worker-", svcName)
+	if address := worker.RayStartParams["address"]; address != svcName+":6380" {
+		t.Fatalf("Expected `%v` but got `%v`", svcName+":6380", address)
+	}
+	if env := findEnvVar(podTemplateSpec.Spec.Containers[0].Env, RAY_PORT); env == nil || env.Value != "6380" {
+		t.Fatalf("Expected `%v` but got `%v`", "6380", env)
+	}
+}
+
+func TestSetResourceRayStartParams(t *testing.T) {
+	container := corev1.Container{
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2500m"),
+				corev1.ResourceMemory: resource.MustParse("10G"),
+				"nvidia.com/gpu":      resource.MustParse("2"),
+			},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("4G"),
+			},
+		},
+	}
+	params := map[string]string{}
+	setResourceRayStartParams(params, container)
+	expected := map[string]string{
+		"num-cpus":            "3",
+		"num-gpus":            "2",
+		"object-store-memory": "3000000000",
+		"memory":              "7000000000",
+	}
+	if !reflect.DeepEqual(expected, params) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, params)
+	}
+
+	// the values set by the user win, the requests are used without limits
+	container.Resources.Limits = nil
+	params = map[string]string{"num-cpus": "0", "object-store-memory": "1000000000"}
+	setResourceRayStartParams(params, container)
+	expected = map[string]string{
+		"num-cpus":            "0",
+		"object-store-memory": "1000000000",
+		"memory":              "3000000000",
+	}
+	if !reflect.DeepEqual(expected, params) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, params)
+	}
+
+	// nothing is set without resources
+	params = map[string]string{}
+	setResourceRayStartParams(params, corev1.Container{})
+	if len(params) != 0 {
+		t.Fatalf("Expected no param but got `%v`", params)
+	}
+}
+
+func TestBuildPodBlocksRayStart(t *testing.T) {
+	cluster := instance.DeepCopy()
+	delete(cluster.Spec.HeadGroupSpec.RayStartParams, "block")
+	svcName := utils.GenerateServiceName(cluster.Name)
+
+	// ray start blocks when nothing runs after it
+	podTemplateSpec := DefaultHeadPodTemplate(*cluster, cluster.Spec.HeadGroupSpec, "raycluster-sample-head-", svcName)
+	podTemplateSpec.Spec.Containers[0].Command = nil
+	podTemplateSpec.Spec.Containers[0].Args = nil
+	pod := BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, svcName, nil)
+	args := pod.Spec.Containers[0].Args[0]
+	if !strings.Contains(args, " --block ") || strings.Contains(args, "sleep infinity") {
+		t.Fatalf("Expected a blocking ray start but got `%v`", args)
+	}
+	if _, ok := cluster.Spec.HeadGroupSpec.RayStartParams["block"]; ok {
+		t.Fatalf("Expected the RayCluster spec to be unchanged but got `%v`", cluster.Spec.HeadGroupSpec.RayStartParams)
+	}
+
+	// ray start returns to run the command of the container, which is followed by sleep infinity
+	podTemplateSpec.Spec.Containers[0].Command = []string{"python"}
+	podTemplateSpec.Spec.Containers[0].Args = []string{"/opt/code.py"}
+	pod = BuildPod(podTemplateSpec, rayiov1alpha1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, svcName, nil)
+	args = pod.Spec.Containers[0].Args[0]
+	if strings.Contains(args, "--block") || !strings.HasSuffix(args, "python  /opt/code.py  && sleep infinity") {
+		t.Fatalf("Expected ray start to return before the command but got `%v`", args)
+	}
+}
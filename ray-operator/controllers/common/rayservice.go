@@ -0,0 +1,58 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// GenerateRayClusterSpecHash returns a hash of the RayCluster spec of a RayService.
+// A RayCluster annotated with a different hash was created from an outdated spec.
+func GenerateRayClusterSpecHash(spec rayiov1alpha1.RayClusterSpec) (string, error) {
+	return generateHash(spec)
+}
+
+// GenerateServeConfigHash returns a hash of the Serve config of a RayService
+func GenerateServeConfigHash(config rayiov1alpha1.ServeConfigSpec) (string, error) {
+	return generateHash(config)
+}
+
+func generateHash(object interface{}) (string, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	hasher := fnv.New32a()
+	hasher.Write(data)
+	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32())), nil
+}
+
+// BuildServeServiceForRayService builds the stable Serve service of a RayService, selecting the head pod of the
+// given RayCluster. Its selector is switched to the new RayCluster once the Serve deployments of that are healthy.
+func BuildServeServiceForRayService(rayService rayiov1alpha1.RayService, cluster rayiov1alpha1.RayCluster) *corev1.Service {
+	port, ok := getServicePorts(cluster)[DefaultServePortName]
+	if !ok {
+		port = DefaultServePort
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateServeServiceName(rayService.Name),
+			Namespace: rayService.Namespace,
+			Labels:    map[string]string{RayServiceLabelKey: rayService.Name},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				RayClusterLabelKey:  cluster.Name,
+				RayNodeTypeLabelKey: string(rayiov1alpha1.HeadNode),
+			},
+			Ports: []corev1.ServicePort{{Name: DefaultServePortName, Port: port}},
+			Type:  corev1.ServiceTypeClusterIP,
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildServeServiceForRayService(t *testing.T) {
+	rayService := rayiov1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice-sample", Namespace: "default"},
+	}
+	cluster := instanceWithWrongSvc.DeepCopy()
+	svc := BuildServeServiceForRayService(rayService, *cluster)
+
+	if svc.Name != "rayservice-sample-serve-svc" {
+		t.Fatalf("Expected `%v` but got `%v`", "rayservice-sample-serve-svc", svc.Name)
+	}
+	if svc.Spec.Selector[RayClusterLabelKey] != cluster.Name {
+		t.Fatalf("Expected `%v` but got `%v`", cluster.Name, svc.Spec.Selector[RayClusterLabelKey])
+	}
+	if svc.Spec.Ports[0].Port != DefaultServePort {
+		t.Fatalf("Expected `%v` but got `%v`", DefaultServePort, svc.Spec.Ports[0].Port)
+	}
+
+	// the serve port of the head container is used
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultServePortName, ContainerPort: 8001},
+	}
+	svc = BuildServeServiceForRayService(rayService, *cluster)
+	if svc.Spec.Ports[0].Port != 8001 {
+		t.Fatalf("Expected `%v` but got `%v`", 8001, svc.Spec.Ports[0].Port)
+	}
+}
+
+func TestGenerateRayClusterSpecHash(t *testing.T) {
+	spec := instanceWithWrongSvc.Spec.DeepCopy()
+	hash, err := GenerateRayClusterSpecHash(*spec)
+	if err != nil {
+		t.Fatalf("Failed to hash the spec: %v", err)
+	}
+	sameHash, _ := GenerateRayClusterSpecHash(*spec.DeepCopy())
+	if hash != sameHash {
+		t.Fatalf("Expected `%v` but got `%v`", hash, sameHash)
+	}
+
+	spec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:1.9.0"
+	newHash, _ := GenerateRayClusterSpecHash(*spec)
+	if hash == newHash {
+		t.Fatalf("Expected the hash to change with the spec")
+	}
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetAutoscalerServiceAccountName returns the service account of the head pod, named after the cluster unless
+// the head template sets one
+func GetAutoscalerServiceAccountName(cluster rayiov1alpha1.RayCluster) string {
+	if name := cluster.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName; name != "" {
+		return name
+	}
+	return cluster.Name
+}
+
+// BuildServiceAccount builds the service account used by the autoscaler of the head pod
+func BuildServiceAccount(cluster rayiov1alpha1.RayCluster) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetAutoscalerServiceAccountName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{RayClusterLabelKey: cluster.Name},
+		},
+	}
+}
+
+// BuildRole builds the role allowing the autoscaler to watch the ray pods and to patch the replicas
+// and workers to delete of the RayCluster
+func BuildRole(cluster rayiov1alpha1.RayCluster) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name,
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{RayClusterLabelKey: cluster.Name},
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{rayiov1alpha1.GroupVersion.Group},
+				Resources: []string{"rayclusters"},
+				Verbs:     []string{"get", "list", "watch", "patch"},
+			},
+		},
+	}
+}
+
+// BuildRoleBinding binds the autoscaler role to the service account of the head pod
+func BuildRoleBinding(cluster rayiov1alpha1.RayCluster) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Name,
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{RayClusterLabelKey: cluster.Name},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      GetAutoscalerServiceAccountName(cluster),
+				Namespace: cluster.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     cluster.Name,
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultRedisPasswordSecretKey is the key of the password in the redis password secret
+	DefaultRedisPasswordSecretKey = "password"
+	// redisPasswordLength is the number of random bytes of a generated password
+	redisPasswordLength = 16
+)
+
+// IsRedisPasswordSecretGenerated returns true if the operator generates the redis password secret of the cluster
+func IsRedisPasswordSecretGenerated(cluster rayiov1alpha1.RayCluster) bool {
+	return cluster.Spec.RedisPassword != nil && cluster.Spec.RedisPassword.SecretName == ""
+}
+
+// GetRedisPasswordSecretName returns the secret holding the redis password, named after the cluster when it is generated
+func GetRedisPasswordSecretName(cluster rayiov1alpha1.RayCluster) string {
+	if cluster.Spec.RedisPassword != nil && cluster.Spec.RedisPassword.SecretName != "" {
+		return cluster.Spec.RedisPassword.SecretName
+	}
+	return cluster.Name + "-redis-password"
+}
+
+// GetRedisPasswordSecretKey returns the key of the password in the redis password secret
+func GetRedisPasswordSecretKey(cluster rayiov1alpha1.RayCluster) string {
+	if cluster.Spec.RedisPassword != nil && cluster.Spec.RedisPassword.SecretKey != "" {
+		return cluster.Spec.RedisPassword.SecretKey
+	}
+	return DefaultRedisPasswordSecretKey
+}
+
+// BuildRedisPasswordSecret builds the secret generated for the cluster with a random password
+func BuildRedisPasswordSecret(cluster rayiov1alpha1.RayCluster) (*corev1.Secret, error) {
+	password := make([]byte, redisPasswordLength)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetRedisPasswordSecretName(cluster),
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{RayClusterLabelKey: cluster.Name},
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			GetRedisPasswordSecretKey(cluster): hex.EncodeToString(password),
+		},
+	}, nil
+}
+
+// setRedisPasswordEnv makes the ray container read the redis password from the secret of the cluster,
+// unless the template already sets REDIS_PASSWORD
+func setRedisPasswordEnv(podSpec *corev1.PodSpec, cluster rayiov1alpha1.RayCluster) {
+	if cluster.Spec.RedisPassword == nil || len(podSpec.Containers) == 0 {
+		return
+	}
+	rayContainer := &podSpec.Containers[getRayContainerIndex(corev1.Pod{Spec: *podSpec})]
+	if envVarExists(REDIS_PASSWORD, rayContainer.Env) {
+		return
+	}
+	rayContainer.Env = append(rayContainer.Env, corev1.EnvVar{
+		Name: REDIS_PASSWORD,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: GetRedisPasswordSecretName(cluster)},
+				Key:                  GetRedisPasswordSecretKey(cluster),
+			},
+		},
+	})
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+)
+
+func TestBuildRedisPasswordSecret(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.RedisPassword = &rayiov1alpha1.RedisPasswordSpec{}
+	if !IsRedisPasswordSecretGenerated(*cluster) {
+		t.Fatalf("Expected the secret to be generated")
+	}
+
+	secret, err := BuildRedisPasswordSecret(*cluster)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if secret.Name != "raycluster-sample-redis-password" {
+		t.Fatalf("Expected `%v` but got `%v`", "raycluster-sample-redis-password", secret.Name)
+	}
+	password := secret.StringData[DefaultRedisPasswordSecretKey]
+	if len(password) != 2*redisPasswordLength {
+		t.Fatalf("Expected `%v` but got `%v`", 2*redisPasswordLength, len(password))
+	}
+	other, err := BuildRedisPasswordSecret(*cluster)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if other.StringData[DefaultRedisPasswordSecretKey] == password {
+		t.Fatalf("Expected the passwords to be random")
+	}
+
+	cluster.Spec.RedisPassword.SecretName = "my-secret"
+	if IsRedisPasswordSecretGenerated(*cluster) {
+		t.Fatalf("Expected the secret not to be generated")
+	}
+}
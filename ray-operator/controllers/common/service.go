@@ -1,10 +1,17 @@
 package common
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // BuildServiceForHeadPod Builds the service for a pod. Currently, there is only one service that allows
@@ -34,40 +41,163 @@ func BuildServiceForHeadPod(cluster rayiov1alpha1.RayCluster) (*corev1.Service,
 		svcPort := corev1.ServicePort{Name: name, Port: port}
 		service.Spec.Ports = append(service.Spec.Ports, svcPort)
 	}
+	// keep a stable order, the ports come from a map
+	sort.Slice(service.Spec.Ports, func(i, j int) bool {
+		return service.Spec.Ports[i].Name < service.Spec.Ports[j].Name
+	})
 
 	return service, nil
 }
 
-// getServicePorts will either user passing ports or default ports to create service.
-func getServicePorts(cluster rayiov1alpha1.RayCluster) map[string]int32 {
-	ports, err := getPortsFromCluster(cluster)
-	// Assign default ports
-	if err != nil || len(ports) == 0 {
-		ports = getDefaultPorts()
+// SyncHeadService copies the type, ports, selector, labels and annotations of the desired head service
+// into the live one. Node ports already allocated are kept. It returns true if the live service was changed.
+func SyncHeadService(live *corev1.Service, desired *corev1.Service) bool {
+	changed := false
+	if live.Spec.Type != desired.Spec.Type {
+		if live.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			live.Spec.LoadBalancerIP = ""
+			live.Spec.LoadBalancerSourceRanges = nil
+		}
+		if desired.Spec.Type == corev1.ServiceTypeClusterIP {
+			// these fields are only valid with node ports
+			live.Spec.ExternalTrafficPolicy = ""
+			live.Spec.HealthCheckNodePort = 0
+		}
+		live.Spec.Type = desired.Spec.Type
+		changed = true
 	}
 
-	return ports
+	ports := make([]corev1.ServicePort, 0, len(desired.Spec.Ports))
+	for _, port := range desired.Spec.Ports {
+		// apply the same defaults as the API server so that unchanged ports compare equal
+		if port.Protocol == "" {
+			port.Protocol = corev1.ProtocolTCP
+		}
+		if port.TargetPort == (intstr.IntOrString{}) {
+			port.TargetPort = intstr.FromInt(int(port.Port))
+		}
+		if port.NodePort == 0 && desired.Spec.Type != corev1.ServiceTypeClusterIP {
+			for _, livePort := range live.Spec.Ports {
+				if livePort.Name == port.Name {
+					port.NodePort = livePort.NodePort
+				}
+			}
+		}
+		ports = append(ports, port)
+	}
+	if !reflect.DeepEqual(live.Spec.Ports, ports) {
+		live.Spec.Ports = ports
+		changed = true
+	}
+
+	if !reflect.DeepEqual(live.Spec.Selector, desired.Spec.Selector) {
+		live.Spec.Selector = desired.Spec.Selector
+		changed = true
+	}
+
+	// labels and annotations set by other controllers are kept
+	for key, value := range desired.Labels {
+		if live.Labels[key] != value {
+			if live.Labels == nil {
+				live.Labels = map[string]string{}
+			}
+			live.Labels[key] = value
+			changed = true
+		}
+	}
+	for key, value := range desired.Annotations {
+		if live.Annotations[key] != value {
+			if live.Annotations == nil {
+				live.Annotations = map[string]string{}
+			}
+			live.Annotations[key] = value
+			changed = true
+		}
+	}
+
+	return changed
 }
 
-// getPortsFromCluster get the ports from head container and directly map them in service
-// It's user's responsibility to maintain rayStartParam ports and container ports mapping
-// TODO: Consider to infer ports from rayStartParams (source of truth) in the future.
-func getPortsFromCluster(cluster rayiov1alpha1.RayCluster) (map[string]int32, error) {
-	svcPorts := map[string]int32{}
+// GetDashboardURL returns the address of the dashboard of the cluster through its head service
+func GetDashboardURL(cluster rayiov1alpha1.RayCluster) string {
+	port, ok := getServicePorts(cluster)[DefaultDashboardName]
+	if !ok {
+		port = DefaultDashboardPort
+	}
+	return fmt.Sprintf("http://%s.%s.svc:%d", utils.GenerateServiceName(cluster.Name), cluster.Namespace, port)
+}
 
-	index := utils.FindRayContainerIndex(cluster.Spec.HeadGroupSpec.Template.Spec)
-	cPorts := cluster.Spec.HeadGroupSpec.Template.Spec.Containers[index].Ports
-	for _, port := range cPorts {
-		svcPorts[port.Name] = port.ContainerPort
+// headPortParams are the ray start params of the head setting the port of a head service port.
+// A zero default means ray picks a random port, the service port only exists when the param is set.
+var headPortParams = []struct {
+	param       string
+	name        string
+	defaultPort int32
+}{
+	{"port", DefaultRedisPortName, DefaultRedisPort},
+	{"ray-client-server-port", DefaultClientPortName, DefaultClientPort},
+	{"dashboard-port", DefaultDashboardName, DefaultDashboardPort},
+	{"metrics-export-port", DefaultMetricsPortName, 0},
+}
+
+// getServicePorts returns the ports of the head service. The ray start params of the head are the source of truth,
+// the other ports declared on the head container, e.g. serve, are kept as is.
+func getServicePorts(cluster rayiov1alpha1.RayCluster) map[string]int32 {
+	ports := map[string]int32{}
+	headSpec := cluster.Spec.HeadGroupSpec.Template.Spec
+	if len(headSpec.Containers) > 0 {
+		for _, port := range headSpec.Containers[utils.FindRayContainerIndex(headSpec)].Ports {
+			if port.Name != "" {
+				ports[port.Name] = port.ContainerPort
+			}
+		}
 	}
+	for _, param := range headPortParams {
+		if port, err := getPortParam(cluster.Spec.HeadGroupSpec.RayStartParams, param.param); err == nil && port != 0 {
+			ports[param.name] = port
+		} else if param.defaultPort != 0 {
+			ports[param.name] = param.defaultPort
+		} else {
+			delete(ports, param.name)
+		}
+	}
+	return ports
+}
 
-	return svcPorts, nil
+// getPortParam parses the port set by a ray start param, it returns 0 when the param is not set
+func getPortParam(rayStartParams map[string]string, param string) (int32, error) {
+	value, ok := rayStartParams[param]
+	if !ok {
+		return 0, nil
+	}
+	port, err := strconv.ParseInt(strings.Trim(value, `"'`), 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, fmt.Errorf("ray start param %s=%s is not a valid port", param, value)
+	}
+	return int32(port), nil
 }
 
-func getDefaultPorts() map[string]int32 {
-	return map[string]int32{
-		DefaultClientPortName: DefaultClientPort,
-		DefaultRedisPortName:  DefaultRedisPort,
-		DefaultDashboardName:  DefaultDashboardPort,
+// GetHeadPortConflicts describes the ray start params of the head which are not valid ports,
+// and the ports declared on the head container which disagree with the ports ray listens on
+func GetHeadPortConflicts(cluster rayiov1alpha1.RayCluster) []string {
+	var conflicts []string
+	servicePorts := getServicePorts(cluster)
+	headSpec := cluster.Spec.HeadGroupSpec.Template.Spec
+	for _, param := range headPortParams {
+		if _, err := getPortParam(cluster.Spec.HeadGroupSpec.RayStartParams, param.param); err != nil {
+			conflicts = append(conflicts, err.Error())
+			continue
+		}
+		expected, ok := servicePorts[param.name]
+		if !ok || len(headSpec.Containers) == 0 {
+			continue
+		}
+		for _, port := range headSpec.Containers[utils.FindRayContainerIndex(headSpec)].Ports {
+			if port.Name == param.name && port.ContainerPort != expected {
+				conflicts = append(conflicts, fmt.Sprintf("container port %s is %d but ray listens on %d, set the %s ray start param instead",
+					port.Name, port.ContainerPort, expected, param.param))
+			}
+		}
 	}
+	return conflicts
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
@@ -10,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -79,3 +81,89 @@ func TestBuildServiceForHeadPod(t *testing.T) {
 		t.Fatalf("Expected `%v` but got `%v`", expectedResult, actualResult)
 	}
 }
+
+func TestGetDashboardURL(t *testing.T) {
+	expectedResult := "http://raycluster-sample-head-svc.default.svc:8265"
+	actualResult := GetDashboardURL(*instanceWithWrongSvc)
+	if expectedResult != actualResult {
+		t.Fatalf("Expected `%v` but got `%v`", expectedResult, actualResult)
+	}
+
+	// the port comes from the ray start params, a container port alone doesn't move the dashboard
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultDashboardName, ContainerPort: 8266},
+	}
+	actualResult = GetDashboardURL(*cluster)
+	if expectedResult != actualResult {
+		t.Fatalf("Expected `%v` but got `%v`", expectedResult, actualResult)
+	}
+
+	cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-port"] = "8266"
+	expectedResult = "http://raycluster-sample-head-svc.default.svc:8266"
+	actualResult = GetDashboardURL(*cluster)
+	if expectedResult != actualResult {
+		t.Fatalf("Expected `%v` but got `%v`", expectedResult, actualResult)
+	}
+}
+
+func TestGetServicePorts(t *testing.T) {
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.RayStartParams["port"] = "6380"
+	cluster.Spec.HeadGroupSpec.RayStartParams["metrics-export-port"] = "8080"
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{Name: DefaultRedisPortName, ContainerPort: 6379},
+		{Name: DefaultServePortName, ContainerPort: 8000},
+	}
+	expected := map[string]int32{
+		DefaultRedisPortName:   6380,
+		DefaultClientPortName:  DefaultClientPort,
+		DefaultDashboardName:   DefaultDashboardPort,
+		DefaultMetricsPortName: 8080,
+		DefaultServePortName:   8000,
+	}
+	if ports := getServicePorts(*cluster); !reflect.DeepEqual(expected, ports) {
+		t.Fatalf("Expected `%v` but got `%v`", expected, ports)
+	}
+
+	// the container port disagreeing with the params is reported
+	conflicts := GetHeadPortConflicts(*cluster)
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "container port redis is 6379 but ray listens on 6380") {
+		t.Fatalf("Expected a redis port conflict but got `%v`", conflicts)
+	}
+	cluster.Spec.HeadGroupSpec.RayStartParams["dashboard-port"] = "dashboard"
+	conflicts = GetHeadPortConflicts(*cluster)
+	if len(conflicts) != 2 || !strings.Contains(conflicts[1], "dashboard-port=dashboard is not a valid port") {
+		t.Fatalf("Expected an invalid dashboard port but got `%v`", conflicts)
+	}
+}
+
+func TestSyncHeadService(t *testing.T) {
+	desired, err := BuildServiceForHeadPod(*instanceWithWrongSvc)
+	assert.Nil(t, err)
+
+	// a live service as returned by the API server, exposed through node ports
+	live := desired.DeepCopy()
+	live.Spec.Type = corev1.ServiceTypeNodePort
+	live.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+	for index := range live.Spec.Ports {
+		live.Spec.Ports[index].Protocol = corev1.ProtocolTCP
+		live.Spec.Ports[index].TargetPort = intstr.FromInt(int(live.Spec.Ports[index].Port))
+		live.Spec.Ports[index].NodePort = int32(30000 + index)
+	}
+	live.Labels["team"] = "ray"
+
+	desired.Spec.Type = corev1.ServiceTypeLoadBalancer
+	assert.True(t, SyncHeadService(live, desired))
+	assert.Equal(t, corev1.ServiceTypeLoadBalancer, live.Spec.Type)
+	// allocated node ports are kept
+	assert.Equal(t, int32(30000), live.Spec.Ports[0].NodePort)
+	assert.Equal(t, "ray", live.Labels["team"])
+	assert.False(t, SyncHeadService(live, desired))
+
+	desired.Spec.Type = corev1.ServiceTypeClusterIP
+	assert.True(t, SyncHeadService(live, desired))
+	assert.Equal(t, int32(0), live.Spec.Ports[0].NodePort)
+	assert.Equal(t, corev1.ServiceExternalTrafficPolicyType(""), live.Spec.ExternalTrafficPolicy)
+	assert.False(t, SyncHeadService(live, desired))
+}
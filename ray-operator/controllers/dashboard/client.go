@@ -0,0 +1,150 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// JobsPath is the path of the job submission API of the dashboard
+	JobsPath = "/api/jobs/"
+
+	defaultTimeout = 10 * time.Second
+)
+
+// ErrNotFound is returned when the dashboard doesn't know the requested object
+var ErrNotFound = fmt.Errorf("not found")
+
+// JobSubmitRequest is the body of a job submission
+type JobSubmitRequest struct {
+	Entrypoint string                 `json:"entrypoint"`
+	JobID      string                 `json:"job_id,omitempty"`
+	RuntimeEnv map[string]interface{} `json:"runtime_env,omitempty"`
+	Metadata   map[string]string      `json:"metadata,omitempty"`
+}
+
+// JobSubmitResponse is the response of a job submission
+type JobSubmitResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// JobInfo is the status of a job, its times are in milliseconds since the epoch
+type JobInfo struct {
+	Status     string `json:"status"`
+	Entrypoint string `json:"entrypoint,omitempty"`
+	Message    string `json:"message,omitempty"`
+	StartTime  int64  `json:"start_time,omitempty"`
+	EndTime    int64  `json:"end_time,omitempty"`
+}
+
+// Client talks to the REST API of the dashboard of a ray head
+type Client interface {
+	// SubmitJob submits a job and returns its id
+	SubmitJob(ctx context.Context, request *JobSubmitRequest) (string, error)
+	// GetJobInfo returns the status of a job, or ErrNotFound if the job server doesn't know it
+	GetJobInfo(ctx context.Context, jobID string) (*JobInfo, error)
+	// ListJobs returns the status of all the jobs submitted to the job server
+	ListJobs(ctx context.Context) ([]JobInfo, error)
+	// StopJob stops a running job
+	StopJob(ctx context.Context, jobID string) error
+	// UpdateServeDeployments deploys the Serve application, the deployments missing from the config are removed
+	UpdateServeDeployments(ctx context.Context, config *ServeConfig) error
+	// GetServeStatus returns the status of the Serve application and of its deployments
+	GetServeStatus(ctx context.Context) (*ServeStatus, error)
+}
+
+// ClientFactory returns a client of the dashboard at the given URL, it is replaced by a fake in the tests
+type ClientFactory func(dashboardURL string) Client
+
+// NewClient returns an HTTP client of the dashboard at the given URL
+func NewClient(dashboardURL string) Client {
+	return &httpClient{
+		client:       &http.Client{Timeout: defaultTimeout},
+		dashboardURL: strings.TrimSuffix(dashboardURL, "/"),
+	}
+}
+
+type httpClient struct {
+	client       *http.Client
+	dashboardURL string
+}
+
+var _ Client = &httpClient{}
+
+// SubmitJob implements Client
+func (c *httpClient) SubmitJob(ctx context.Context, request *JobSubmitRequest) (string, error) {
+	response := JobSubmitResponse{}
+	if err := c.do(ctx, http.MethodPost, JobsPath, request, &response); err != nil {
+		return "", err
+	}
+	return response.JobID, nil
+}
+
+// GetJobInfo implements Client
+func (c *httpClient) GetJobInfo(ctx context.Context, jobID string) (*JobInfo, error) {
+	info := JobInfo{}
+	if err := c.do(ctx, http.MethodGet, JobsPath+jobID, nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// ListJobs implements Client
+func (c *httpClient) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	jobs := []JobInfo{}
+	if err := c.do(ctx, http.MethodGet, JobsPath, nil, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// StopJob implements Client
+func (c *httpClient) StopJob(ctx context.Context, jobID string) error {
+	return c.do(ctx, http.MethodPost, JobsPath+jobID+"/stop", nil, nil)
+}
+
+// do sends the request with the JSON encoded body and decodes the JSON response into result, if it is not nil
+func (c *httpClient) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	request, err := http.NewRequest(method, c.dashboardURL+path, reader)
+	if err != nil {
+		return err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %d: %s", method, path, response.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, result)
+}
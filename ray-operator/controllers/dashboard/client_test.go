@@ -0,0 +1,88 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSubmitJob(t *testing.T) {
+	var received JobSubmitRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != JobsPath {
+			t.Fatalf("Expected `POST %v` but got `%v %v`", JobsPath, r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("Failed to decode the request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"job_id": "` + received.JobID + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL + "/")
+	jobID, err := client.SubmitJob(context.Background(), &JobSubmitRequest{
+		Entrypoint: "python job.py",
+		JobID:      "rayjob-sample-abcde",
+		RuntimeEnv: map[string]interface{}{"pip": []interface{}{"requests"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to submit the job: %v", err)
+	}
+	if jobID != "rayjob-sample-abcde" {
+		t.Fatalf("Expected `%v` but got `%v`", "rayjob-sample-abcde", jobID)
+	}
+	if received.Entrypoint != "python job.py" {
+		t.Fatalf("Expected `%v` but got `%v`", "python job.py", received.Entrypoint)
+	}
+}
+
+func TestGetJobInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case JobsPath + "known":
+			_, _ = w.Write([]byte(`{"status": "SUCCEEDED", "message": "done", "start_time": 1000, "end_time": 2000}`))
+		case JobsPath + "broken":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	info, err := client.GetJobInfo(context.Background(), "known")
+	if err != nil {
+		t.Fatalf("Failed to get the job: %v", err)
+	}
+	expected := JobInfo{Status: "SUCCEEDED", Message: "done", StartTime: 1000, EndTime: 2000}
+	if *info != expected {
+		t.Fatalf("Expected `%v` but got `%v`", expected, *info)
+	}
+
+	if _, err := client.GetJobInfo(context.Background(), "unknown"); err != ErrNotFound {
+		t.Fatalf("Expected `%v` but got `%v`", ErrNotFound, err)
+	}
+	if _, err := client.GetJobInfo(context.Background(), "broken"); err == nil || err == ErrNotFound {
+		t.Fatalf("Expected a server error but got `%v`", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != JobsPath {
+			t.Fatalf("Expected `GET %v` but got `%v %v`", JobsPath, r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`[{"status": "RUNNING", "start_time": 1000}, {"status": "FAILED", "end_time": 2000}]`))
+	}))
+	defer server.Close()
+
+	jobs, err := NewClient(server.URL).ListJobs(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list the jobs: %v", err)
+	}
+	if len(jobs) != 2 || jobs[0].Status != "RUNNING" || jobs[1].EndTime != 2000 {
+		t.Fatalf("Expected 2 jobs but got `%v`", jobs)
+	}
+}
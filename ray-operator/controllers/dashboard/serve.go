@@ -0,0 +1,63 @@
+package dashboard
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// ServeDeploymentsPath is the path of the Serve API of the dashboard
+	ServeDeploymentsPath = "/api/serve/deployments/"
+	// ServeStatusPath is the path of the status of the Serve application
+	ServeStatusPath = "/api/serve/deployments/status"
+)
+
+// ServeDeploymentConfig overrides the options of a deployment of the Serve application
+type ServeDeploymentConfig struct {
+	Name                 string                 `json:"name"`
+	NumReplicas          *int32                 `json:"num_replicas,omitempty"`
+	RoutePrefix          string                 `json:"route_prefix,omitempty"`
+	MaxConcurrentQueries *int32                 `json:"max_concurrent_queries,omitempty"`
+	UserConfig           map[string]interface{} `json:"user_config,omitempty"`
+}
+
+// ServeConfig is the body of a Serve deployment
+type ServeConfig struct {
+	ImportPath  string                  `json:"import_path"`
+	RuntimeEnv  map[string]interface{}  `json:"runtime_env,omitempty"`
+	Deployments []ServeDeploymentConfig `json:"deployments,omitempty"`
+}
+
+// ServeAppStatus is the status of the Serve application, e.g. DEPLOYING or RUNNING
+type ServeAppStatus struct {
+	Status              string  `json:"status"`
+	Message             string  `json:"message,omitempty"`
+	DeploymentTimestamp float64 `json:"deployment_timestamp,omitempty"`
+}
+
+// ServeDeploymentStatus is the health of a deployment, HEALTHY, UPDATING or UNHEALTHY
+type ServeDeploymentStatus struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// ServeStatus is the status of the Serve application and of its deployments
+type ServeStatus struct {
+	AppStatus          ServeAppStatus          `json:"app_status"`
+	DeploymentStatuses []ServeDeploymentStatus `json:"deployment_statuses"`
+}
+
+// UpdateServeDeployments implements Client
+func (c *httpClient) UpdateServeDeployments(ctx context.Context, config *ServeConfig) error {
+	return c.do(ctx, http.MethodPut, ServeDeploymentsPath, config, nil)
+}
+
+// GetServeStatus implements Client
+func (c *httpClient) GetServeStatus(ctx context.Context) (*ServeStatus, error) {
+	status := ServeStatus{}
+	if err := c.do(ctx, http.MethodGet, ServeStatusPath, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
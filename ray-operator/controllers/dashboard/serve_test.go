@@ -0,0 +1,50 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/utils/pointer"
+)
+
+func TestServe(t *testing.T) {
+	var deployed ServeConfig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == ServeDeploymentsPath:
+			if err := json.NewDecoder(r.Body).Decode(&deployed); err != nil {
+				t.Fatalf("Failed to decode the request: %v", err)
+			}
+		case r.Method == http.MethodGet && r.URL.Path == ServeStatusPath:
+			_, _ = w.Write([]byte(`{"app_status": {"status": "RUNNING"}, "deployment_statuses": [{"name": "MangoStand", "status": "HEALTHY"}]}`))
+		default:
+			t.Fatalf("Unexpected request `%v %v`", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	config := &ServeConfig{
+		ImportPath: "fruit.deployment_graph",
+		Deployments: []ServeDeploymentConfig{
+			{Name: "MangoStand", NumReplicas: pointer.Int32Ptr(2), UserConfig: map[string]interface{}{"price": float64(3)}},
+		},
+	}
+	if err := client.UpdateServeDeployments(context.Background(), config); err != nil {
+		t.Fatalf("Failed to deploy: %v", err)
+	}
+	if deployed.ImportPath != "fruit.deployment_graph" || *deployed.Deployments[0].NumReplicas != 2 {
+		t.Fatalf("Expected `%v` but got `%v`", *config, deployed)
+	}
+
+	status, err := client.GetServeStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get the status: %v", err)
+	}
+	if status.AppStatus.Status != "RUNNING" || len(status.DeploymentStatuses) != 1 || status.DeploymentStatuses[0].Status != "HEALTHY" {
+		t.Fatalf("Expected a healthy application but got `%v`", *status)
+	}
+}
@@ -0,0 +1,126 @@
+package expectations
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ExpectationsTimeout is the time after which the expectations of a group are considered satisfied even if some
+// of the pod events were never observed, so a missed event can't block the scaling of the group forever
+const ExpectationsTimeout = 5 * time.Minute
+
+// HeadKey returns the key of the expectations of the head pod of a cluster
+func HeadKey(namespace string, cluster string) string {
+	return clusterKeyPrefix(namespace, cluster) + "head"
+}
+
+// GroupKey returns the key of the expectations of a worker group of a cluster.
+// The worker keys have their own prefix so that no group name collides with the head key.
+func GroupKey(namespace string, cluster string, group string) string {
+	return clusterKeyPrefix(namespace, cluster) + "worker/" + group
+}
+
+func clusterKeyPrefix(namespace string, cluster string) string {
+	return namespace + "/" + cluster + "/"
+}
+
+// PodExpectations tracks the pods created and deleted by the operator until their events are observed through the
+// informer cache, like the expectations of the ReplicaSet controller. The scaling of a group is skipped while its
+// expectations are not satisfied, as the cache doesn't reflect the pods of the previous scaling yet.
+type PodExpectations struct {
+	mu    sync.Mutex
+	items map[string]*expectation
+	// now is replaced in the tests
+	now func() time.Time
+}
+
+type expectation struct {
+	creations int
+	deletions sets.String
+	timestamp time.Time
+}
+
+// NewPodExpectations returns an empty expectations tracker
+func NewPodExpectations() *PodExpectations {
+	return &PodExpectations{
+		items: map[string]*expectation{},
+		now:   time.Now,
+	}
+}
+
+func (e *PodExpectations) get(key string) *expectation {
+	item, ok := e.items[key]
+	if !ok {
+		item = &expectation{deletions: sets.NewString()}
+		e.items[key] = item
+	}
+	return item
+}
+
+// ExpectCreations records that count pods of the group are about to be created
+func (e *PodExpectations) ExpectCreations(key string, count int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item := e.get(key)
+	item.creations += count
+	item.timestamp = e.now()
+}
+
+// CreationObserved lowers the pending creations of the group, it is called when the creation of a pod is observed
+// or when a pod could not be created
+func (e *PodExpectations) CreationObserved(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if item, ok := e.items[key]; ok && item.creations > 0 {
+		item.creations--
+	}
+}
+
+// ExpectDeletions records that the given pods of the group are about to be deleted
+func (e *PodExpectations) ExpectDeletions(key string, podNames ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item := e.get(key)
+	item.deletions.Insert(podNames...)
+	item.timestamp = e.now()
+}
+
+// DeletionObserved removes a pod from the pending deletions of the group, it is called when the deletion of a pod
+// is observed or when a pod could not be deleted. Pods deleted by someone else are ignored.
+func (e *PodExpectations) DeletionObserved(key string, podName string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if item, ok := e.items[key]; ok {
+		item.deletions.Delete(podName)
+	}
+}
+
+// SatisfiedExpectations returns true if all the pods created and deleted for the group were observed,
+// or if the expectations expired
+func (e *PodExpectations) SatisfiedExpectations(key string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item, ok := e.items[key]
+	if !ok {
+		return true
+	}
+	if item.creations <= 0 && item.deletions.Len() == 0 {
+		return true
+	}
+	return e.now().Sub(item.timestamp) > ExpectationsTimeout
+}
+
+// DeleteClusterExpectations forgets the expectations of all the groups of a cluster
+func (e *PodExpectations) DeleteClusterExpectations(namespace string, cluster string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prefix := clusterKeyPrefix(namespace, cluster)
+	for key := range e.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.items, key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package expectations
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPodExpectations(t *testing.T) {
+	now := time.Now()
+	e := NewPodExpectations()
+	e.now = func() time.Time { return now }
+	key := GroupKey("default", "raycluster-sample", "small-group")
+
+	if !e.SatisfiedExpectations(key) {
+		t.Fatalf("Expected the expectations of an unknown group to be satisfied")
+	}
+
+	e.ExpectCreations(key, 2)
+	e.CreationObserved(key)
+	if e.SatisfiedExpectations(key) {
+		t.Fatalf("Expected a pending creation")
+	}
+	e.CreationObserved(key)
+	if !e.SatisfiedExpectations(key) {
+		t.Fatalf("Expected all the creations to be observed")
+	}
+
+	e.ExpectDeletions(key, "pod-1", "pod-2")
+	e.DeletionObserved(key, "pod-1")
+	// a pod deleted by someone else doesn't count
+	e.DeletionObserved(key, "pod-3")
+	if e.SatisfiedExpectations(key) {
+		t.Fatalf("Expected a pending deletion")
+	}
+	e.DeletionObserved(key, "pod-2")
+	if !e.SatisfiedExpectations(key) {
+		t.Fatalf("Expected all the deletions to be observed")
+	}
+
+	// the other groups are not affected
+	otherKey := GroupKey("default", "raycluster-sample", "large-group")
+	e.ExpectCreations(otherKey, 1)
+	if !e.SatisfiedExpectations(key) || e.SatisfiedExpectations(otherKey) {
+		t.Fatalf("Expected only the expectations of %s to be pending", otherKey)
+	}
+
+	// the head doesn't share the expectations of a worker group named like it
+	headKey := HeadKey("default", "raycluster-sample")
+	e.ExpectDeletions(headKey, "head-1")
+	if e.SatisfiedExpectations(headKey) || !e.SatisfiedExpectations(GroupKey("default", "raycluster-sample", "headgroup")) {
+		t.Fatalf("Expected only the expectations of %s to be pending", headKey)
+	}
+	e.DeletionObserved(headKey, "head-1")
+
+	// the expectations expire when the events are missed
+	now = now.Add(ExpectationsTimeout + time.Second)
+	if !e.SatisfiedExpectations(otherKey) {
+		t.Fatalf("Expected the expectations to expire")
+	}
+}
+
+func TestDeleteClusterExpectations(t *testing.T) {
+	e := NewPodExpectations()
+	key := GroupKey("default", "raycluster-sample", "small-group")
+	headKey := HeadKey("default", "raycluster-sample")
+	otherKey := GroupKey("default", "raycluster-sample-2", "small-group")
+	e.ExpectCreations(key, 1)
+	e.ExpectCreations(headKey, 1)
+	e.ExpectCreations(otherKey, 1)
+
+	e.DeleteClusterExpectations("default", "raycluster-sample")
+	if !e.SatisfiedExpectations(key) || !e.SatisfiedExpectations(headKey) {
+		t.Fatalf("Expected the expectations of the cluster to be deleted")
+	}
+	if e.SatisfiedExpectations(otherKey) {
+		t.Fatalf("Expected the expectations of the other cluster to be kept")
+	}
+}
@@ -0,0 +1,94 @@
+package idle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Probe tells the RayCluster controller since when a cluster has no activity
+type Probe interface {
+	// IdleSince returns the time since which the cluster is idle, or nil if it is busy
+	IdleSince(ctx context.Context, cluster *rayiov1alpha1.RayCluster) (*time.Time, error)
+}
+
+// NewProbe returns the probe with the given name, annotation or dashboard
+func NewProbe(name string) (Probe, error) {
+	switch name {
+	case "annotation":
+		return &AnnotationProbe{}, nil
+	case "dashboard":
+		return &DashboardProbe{DashboardClient: dashboard.NewClient}, nil
+	}
+	return nil, fmt.Errorf("unknown idle probe %q, expected annotation or dashboard", name)
+}
+
+// AnnotationProbe reads the last activity from the ray.io/last-activity-time annotation, which is set
+// by the users or their tools, e.g. a notebook extension. The cluster is idle since that time, or since
+// its head became ready if that is more recent.
+type AnnotationProbe struct{}
+
+var _ Probe = &AnnotationProbe{}
+
+// IdleSince implements Probe
+func (p *AnnotationProbe) IdleSince(_ context.Context, cluster *rayiov1alpha1.RayCluster) (*time.Time, error) {
+	since := startTime(cluster)
+	value, ok := cluster.Annotations[common.RayLastActivityAnnotationKey]
+	if !ok {
+		return &since, nil
+	}
+	lastActivity, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %v", common.RayLastActivityAnnotationKey, err)
+	}
+	if lastActivity.After(since) {
+		since = lastActivity
+	}
+	return &since, nil
+}
+
+// DashboardProbe asks the job server of the head for the jobs of the cluster. The cluster is busy while a job
+// is not finished, it is idle since the end of its last job, or since its head became ready if that is more recent.
+type DashboardProbe struct {
+	DashboardClient dashboard.ClientFactory
+}
+
+var _ Probe = &DashboardProbe{}
+
+// IdleSince implements Probe
+func (p *DashboardProbe) IdleSince(ctx context.Context, cluster *rayiov1alpha1.RayCluster) (*time.Time, error) {
+	since := startTime(cluster)
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(rayiov1alpha1.HeadPodReady)) {
+		// the job server can't be reached, the cluster didn't run anything since the head became ready
+		return &since, nil
+	}
+	jobs, err := p.DashboardClient(common.GetDashboardURL(*cluster)).ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if !rayiov1alpha1.IsJobTerminal(rayiov1alpha1.JobStatus(job.Status)) {
+			return nil, nil
+		}
+		// the times of the job server are in milliseconds since the epoch
+		if end := time.Unix(0, job.EndTime*int64(time.Millisecond)); end.After(since) {
+			since = end
+		}
+	}
+	return &since, nil
+}
+
+// startTime is the time the head of the cluster became ready, or the creation of the cluster
+func startTime(cluster *rayiov1alpha1.RayCluster) time.Time {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayiov1alpha1.HeadPodReady))
+	if condition != nil && condition.Status == metav1.ConditionTrue && condition.LastTransitionTime.After(cluster.CreationTimestamp.Time) {
+		return condition.LastTransitionTime.Time
+	}
+	return cluster.CreationTimestamp.Time
+}
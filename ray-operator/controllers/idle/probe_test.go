@@ -0,0 +1,130 @@
+package idle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var created = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func newCluster(headReadyAt *time.Time, annotations map[string]string) *rayiov1alpha1.RayCluster {
+	cluster := &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "raycluster-sample",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations:       annotations,
+		},
+		Spec: rayiov1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayiov1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:1.12.0"}}},
+				},
+			},
+		},
+	}
+	if headReadyAt != nil {
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type:               string(rayiov1alpha1.HeadPodReady),
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(*headReadyAt),
+		}}
+	}
+	return cluster
+}
+
+func TestAnnotationProbe(t *testing.T) {
+	headReady := created.Add(time.Minute)
+	lastActivity := created.Add(time.Hour)
+	tests := map[string]struct {
+		cluster  *rayiov1alpha1.RayCluster
+		expected time.Time
+	}{
+		"no annotation": {
+			cluster:  newCluster(nil, nil),
+			expected: created,
+		},
+		"no annotation, head ready": {
+			cluster:  newCluster(&headReady, nil),
+			expected: headReady,
+		},
+		"activity after the head became ready": {
+			cluster:  newCluster(&headReady, map[string]string{common.RayLastActivityAnnotationKey: lastActivity.Format(time.RFC3339)}),
+			expected: lastActivity,
+		},
+		"activity before the head became ready": {
+			cluster:  newCluster(&lastActivity, map[string]string{common.RayLastActivityAnnotationKey: headReady.Format(time.RFC3339)}),
+			expected: lastActivity,
+		},
+	}
+	for name, test := range tests {
+		since, err := (&AnnotationProbe{}).IdleSince(context.Background(), test.cluster)
+		if err != nil {
+			t.Fatalf("%s: failed to probe: %v", name, err)
+		}
+		if !since.Equal(test.expected) {
+			t.Fatalf("%s: Expected `%v` but got `%v`", name, test.expected, since)
+		}
+	}
+
+	cluster := newCluster(nil, map[string]string{common.RayLastActivityAnnotationKey: "yesterday"})
+	if _, err := (&AnnotationProbe{}).IdleSince(context.Background(), cluster); err == nil {
+		t.Fatalf("Expected an error for an invalid annotation")
+	}
+}
+
+// fakeJobsClient only implements ListJobs of the dashboard client
+type fakeJobsClient struct {
+	dashboard.Client
+	jobs []dashboard.JobInfo
+}
+
+func (c *fakeJobsClient) ListJobs(_ context.Context) ([]dashboard.JobInfo, error) {
+	return c.jobs, nil
+}
+
+func TestDashboardProbe(t *testing.T) {
+	headReady := created.Add(time.Minute)
+	jobEnd := created.Add(time.Hour)
+	client := &fakeJobsClient{}
+	probe := &DashboardProbe{DashboardClient: func(string) dashboard.Client { return client }}
+
+	// a running job keeps the cluster busy
+	client.jobs = []dashboard.JobInfo{
+		{Status: string(rayiov1alpha1.JobStatusSucceeded), EndTime: jobEnd.UnixNano() / int64(time.Millisecond)},
+		{Status: string(rayiov1alpha1.JobStatusRunning)},
+	}
+	since, err := probe.IdleSince(context.Background(), newCluster(&headReady, nil))
+	if err != nil {
+		t.Fatalf("Failed to probe: %v", err)
+	}
+	if since != nil {
+		t.Fatalf("Expected the cluster to be busy but it is idle since `%v`", since)
+	}
+
+	// the cluster is idle since the end of its last job
+	client.jobs = client.jobs[:1]
+	since, err = probe.IdleSince(context.Background(), newCluster(&headReady, nil))
+	if err != nil {
+		t.Fatalf("Failed to probe: %v", err)
+	}
+	if since == nil || !since.Equal(jobEnd) {
+		t.Fatalf("Expected `%v` but got `%v`", jobEnd, since)
+	}
+
+	// the job server isn't asked before the head is ready
+	since, err = probe.IdleSince(context.Background(), newCluster(nil, nil))
+	if err != nil {
+		t.Fatalf("Failed to probe: %v", err)
+	}
+	if since == nil || !since.Equal(created) {
+		t.Fatalf("Expected `%v` but got `%v`", created, since)
+	}
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kuberay"
+
+// clusterStates are the values reported by the state gauge
+var clusterStates = []rayiov1alpha1.ClusterState{rayiov1alpha1.Ready, rayiov1alpha1.UnHealthy, rayiov1alpha1.Failed, rayiov1alpha1.Suspended}
+
+var (
+	clusterLabels = []string{"namespace", "cluster"}
+	podLabels     = []string{"namespace", "node_type", "group"}
+
+	// ClusterState is 1 for the current state of the cluster and 0 for the other states
+	ClusterState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_state",
+		Help:      "State of the RayCluster, 1 for the current state.",
+	}, append(clusterLabels, "state"))
+	// DesiredWorkers is the number of workers requested by the spec
+	DesiredWorkers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_desired_workers",
+		Help:      "Number of desired workers of the RayCluster.",
+	}, clusterLabels)
+	// AvailableWorkers is the number of running workers
+	AvailableWorkers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_available_workers",
+		Help:      "Number of available workers of the RayCluster.",
+	}, clusterLabels)
+	// MinWorkers is the sum of the min replicas of the worker groups
+	MinWorkers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_min_workers",
+		Help:      "Minimum number of workers of the RayCluster.",
+	}, clusterLabels)
+	// MaxWorkers is the sum of the max replicas of the worker groups
+	MaxWorkers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_max_workers",
+		Help:      "Maximum number of workers of the RayCluster.",
+	}, clusterLabels)
+
+	// PodsCreated counts the pods created by the operator
+	PodsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cluster_pods_created_total",
+		Help:      "Number of ray pods created.",
+	}, podLabels)
+	// PodsDeleted counts the pods deleted by the operator
+	PodsDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cluster_pods_deleted_total",
+		Help:      "Number of ray pods deleted.",
+	}, podLabels)
+	// PodsFailed counts the pods the operator failed to create
+	PodsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cluster_pods_failed_total",
+		Help:      "Number of ray pods that failed to be created.",
+	}, podLabels)
+
+	// ProvisioningDuration is the time from the creation of a RayCluster to its first ready state
+	ProvisioningDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cluster_provisioning_duration_seconds",
+		Help:      "Time from the creation of the RayCluster to its first ready state.",
+		Buckets:   []float64{10, 30, 60, 120, 180, 300, 600, 900, 1200, 1800, 3600},
+	})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		ClusterState,
+		DesiredWorkers,
+		AvailableWorkers,
+		MinWorkers,
+		MaxWorkers,
+		PodsCreated,
+		PodsDeleted,
+		PodsFailed,
+		ProvisioningDuration,
+	)
+}
+
+// UpdateClusterMetrics sets the gauges of the cluster from its status
+func UpdateClusterMetrics(cluster *rayiov1alpha1.RayCluster) {
+	for _, state := range clusterStates {
+		value := 0.0
+		if cluster.Status.State == state {
+			value = 1
+		}
+		ClusterState.WithLabelValues(cluster.Namespace, cluster.Name, string(state)).Set(value)
+	}
+	DesiredWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.DesiredWorkerReplicas))
+	AvailableWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.AvailableWorkerReplicas))
+	MinWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.MinWorkerReplicas))
+	MaxWorkers.WithLabelValues(cluster.Namespace, cluster.Name).Set(float64(cluster.Status.MaxWorkerReplicas))
+}
+
+// ObserveClusterReady records the provisioning duration of a cluster which just became ready for the first time.
+// The caller keeps track of the first ready state in the cluster status so that it survives operator restarts.
+func ObserveClusterReady(cluster *rayiov1alpha1.RayCluster) {
+	ProvisioningDuration.Observe(cluster.Status.LastStateTransitionTime.Sub(cluster.CreationTimestamp.Time).Seconds())
+}
+
+// DeleteClusterMetrics removes the series of a deleted cluster
+func DeleteClusterMetrics(name types.NamespacedName) {
+	for _, state := range clusterStates {
+		ClusterState.DeleteLabelValues(name.Namespace, name.Name, string(state))
+	}
+	for _, vec := range []*prometheus.GaugeVec{DesiredWorkers, AvailableWorkers, MinWorkers, MaxWorkers} {
+		vec.DeleteLabelValues(name.Namespace, name.Name)
+	}
+}
+
+// RecordPodCreated counts a ray pod created by the operator
+func RecordPodCreated(namespace string, nodeType rayiov1alpha1.RayNodeType, group string) {
+	PodsCreated.WithLabelValues(namespace, string(nodeType), group).Inc()
+}
+
+// RecordPodDeleted counts a ray pod deleted by the operator
+func RecordPodDeleted(namespace string, nodeType rayiov1alpha1.RayNodeType, group string) {
+	PodsDeleted.WithLabelValues(namespace, string(nodeType), group).Inc()
+}
+
+// RecordPodFailed counts a ray pod the operator failed to create
+func RecordPodFailed(namespace string, nodeType rayiov1alpha1.RayNodeType, group string) {
+	PodsFailed.WithLabelValues(namespace, string(nodeType), group).Inc()
+}
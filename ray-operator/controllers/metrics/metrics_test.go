@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestUpdateClusterMetrics(t *testing.T) {
+	created := time.Now().Add(-time.Minute)
+	cluster := &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "raycluster-metrics",
+			Namespace:         "default",
+			UID:               "uid-1",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: rayiov1alpha1.RayClusterStatus{
+			State:                   rayiov1alpha1.Ready,
+			DesiredWorkerReplicas:   3,
+			AvailableWorkerReplicas: 2,
+			MinWorkerReplicas:       1,
+			MaxWorkerReplicas:       5,
+			LastStateTransitionTime: metav1.NewTime(created.Add(time.Minute)),
+		},
+	}
+
+	UpdateClusterMetrics(cluster)
+	if value := testutil.ToFloat64(ClusterState.WithLabelValues("default", "raycluster-metrics", "ready")); value != 1 {
+		t.Fatalf("Expected `%v` but got `%v`", 1, value)
+	}
+	if value := testutil.ToFloat64(ClusterState.WithLabelValues("default", "raycluster-metrics", "unHealthy")); value != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, value)
+	}
+	if value := testutil.ToFloat64(AvailableWorkers.WithLabelValues("default", "raycluster-metrics")); value != 2 {
+		t.Fatalf("Expected `%v` but got `%v`", 2, value)
+	}
+
+	ObserveClusterReady(cluster)
+	metric := &dto.Metric{}
+	if err := ProvisioningDuration.Write(metric); err != nil {
+		t.Fatalf("Expected `%v` but got `%v`", nil, err)
+	}
+	if count := metric.GetHistogram().GetSampleCount(); count != 1 {
+		t.Fatalf("Expected `%v` but got `%v`", 1, count)
+	}
+	if sum := metric.GetHistogram().GetSampleSum(); sum != 60 {
+		t.Fatalf("Expected `%v` but got `%v`", 60, sum)
+	}
+
+	DeleteClusterMetrics(types.NamespacedName{Namespace: "default", Name: "raycluster-metrics"})
+	if count := testutil.CollectAndCount(DesiredWorkers); count != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, count)
+	}
+}
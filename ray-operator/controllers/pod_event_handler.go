@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/expectations"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// podEventHandler enqueues the RayCluster owning the pod and records the creations and deletions
+// of the pods in the expectations of their group
+type podEventHandler struct {
+	*handler.EnqueueRequestForOwner
+	expectations *expectations.PodExpectations
+}
+
+var _ handler.EventHandler = &podEventHandler{}
+
+// Create implements handler.EventHandler
+func (h *podEventHandler) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	if key, ok := podGroupKey(evt.Object); ok {
+		h.expectations.CreationObserved(key)
+	}
+	h.EnqueueRequestForOwner.Create(evt, q)
+}
+
+// Update implements handler.EventHandler, a pod with a deletion timestamp is observed as deleted
+// since it doesn't count as a running pod anymore
+func (h *podEventHandler) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	if evt.ObjectNew.GetDeletionTimestamp() != nil {
+		if key, ok := podGroupKey(evt.ObjectNew); ok {
+			h.expectations.DeletionObserved(key, evt.ObjectNew.GetName())
+		}
+	}
+	h.EnqueueRequestForOwner.Update(evt, q)
+}
+
+// Delete implements handler.EventHandler
+func (h *podEventHandler) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	if key, ok := podGroupKey(evt.Object); ok {
+		h.expectations.DeletionObserved(key, evt.Object.GetName())
+	}
+	h.EnqueueRequestForOwner.Delete(evt, q)
+}
+
+// podGroupKey returns the expectations key of a ray pod, the head pod has its own key
+func podGroupKey(pod client.Object) (string, bool) {
+	labels := pod.GetLabels()
+	cluster, group := labels[common.RayClusterLabelKey], labels[common.RayNodeGroupLabelKey]
+	if cluster == "" {
+		return "", false
+	}
+	if labels[common.RayNodeTypeLabelKey] == string(rayiov1alpha1.HeadNode) {
+		return expectations.HeadKey(pod.GetNamespace(), cluster), true
+	}
+	if group == "" {
+		return "", false
+	}
+	return expectations.GroupKey(pod.GetNamespace(), cluster, group), true
+}
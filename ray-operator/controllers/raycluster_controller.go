@@ -3,12 +3,19 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/batchscheduler"
 	"github.com/ray-project/kuberay/ray-operator/controllers/common"
 	_ "github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/expectations"
+	"github.com/ray-project/kuberay/ray-operator/controllers/idle"
+	"github.com/ray-project/kuberay/ray-operator/controllers/metrics"
+	"github.com/ray-project/kuberay/ray-operator/controllers/scaledown"
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 
 	"k8s.io/client-go/tools/record"
@@ -19,9 +26,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,6 +49,12 @@ import (
 var (
 	log                    = logf.Log.WithName("raycluster-controller")
 	DefaultRequeueDuration = 2 * time.Second
+	// DefaultMaxCreationBatchSize is the default number of worker pods created in parallel
+	DefaultMaxCreationBatchSize = 100
+	// DefaultExpirationWarningPeriod is how long before the expiration of a cluster warning events are emitted
+	DefaultExpirationWarningPeriod = 10 * time.Minute
+	// IdleProbeInterval is how often the idle probe is asked about clusters with an idle timeout
+	IdleProbeInterval = time.Minute
 )
 
 // NewReconciler returns a new reconcile.Reconciler
@@ -46,6 +64,12 @@ func NewReconciler(mgr manager.Manager) *RayClusterReconciler {
 		Scheme:   mgr.GetScheme(),
 		Log:      ctrl.Log.WithName("controllers").WithName("RayCluster"),
 		Recorder: mgr.GetEventRecorderFor("raycluster-controller"),
+
+		Expectations:            expectations.NewPodExpectations(),
+		MaxCreationBatchSize:    DefaultMaxCreationBatchSize,
+		IdleProbe:               &idle.AnnotationProbe{},
+		ExpirationWarningPeriod: DefaultExpirationWarningPeriod,
+		CrashLoopPolicy:         utils.DefaultCrashLoopPolicy(),
 	}
 }
 
@@ -57,6 +81,17 @@ type RayClusterReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// Expectations tracks the pods created and deleted until the cache observes them
+	Expectations *expectations.PodExpectations
+	// MaxCreationBatchSize caps the number of worker pods created in parallel
+	MaxCreationBatchSize int
+	// IdleProbe reports since when the clusters with an idle timeout have no activity
+	IdleProbe idle.Probe
+	// ExpirationWarningPeriod is how long before the expiration of a cluster warning events are emitted
+	ExpirationWarningPeriod time.Duration
+	// CrashLoopPolicy flags the worker groups with too many failed workers as crash looping
+	CrashLoopPolicy utils.CrashLoopPolicy
 }
 
 // Reconcile reads that state of the cluster for a RayCluster object and makes changes based on it
@@ -64,12 +99,20 @@ type RayClusterReconciler struct {
 // Automatically generate RBAC rules to allow the Controller to read and write workloads
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizer,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=scheduling.sigs.k8s.io,resources=podgroups,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // Reconcile used to bridge the desired state with the current state
 func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
@@ -79,80 +122,317 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// Fetch the RayCluster instance
 	instance := &rayiov1alpha1.RayCluster{}
 	if err := r.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+		if errors.IsNotFound(err) {
+			metrics.DeleteClusterMetrics(request.NamespacedName)
+			r.Expectations.DeleteClusterExpectations(request.Namespace, request.Name)
+		}
 		log.Error(err, "Read request instance error!")
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
 	if instance.DeletionTimestamp != nil && !instance.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(instance, common.RayClusterFinalizer) {
+			log.Info("RayCluster is being deleted, shutting it down gracefully", "cluster name", request.Name)
+			return r.reconcileShutdown(instance)
+		}
 		log.Info("RayCluser is being deleted, just ignore", "cluster name", request.Name)
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.reconcileIngress(instance); err != nil {
+	if err := r.reconcileFinalizer(instance); err != nil {
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
-	if err := r.reconcileServices(instance); err != nil {
+
+	requeueAfter, deleted, err := r.reconcileExpiration(instance)
+	if err != nil {
 		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
 	}
-	if err := r.reconcilePods(instance); err != nil {
-		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	if deleted {
+		return ctrl.Result{}, nil
+	}
+
+	reconcileFuncs := []reconcileFunc{
+		r.reconcileIngress,
+		r.reconcileServices,
+		r.reconcileAutoscalerRBAC,
+		r.reconcileRedisPasswordSecret,
+		r.reconcilePodGroup,
+		r.reconcilePodDisruptionBudgets,
+		r.reconcileNetworkPolicy,
+		r.reconcilePods,
 	}
 
+	for _, fn := range reconcileFuncs {
+		if reconcileErr := fn(instance); reconcileErr != nil {
+			setCondition(instance, rayiov1alpha1.ReconcileError, metav1.ConditionTrue, "ReconcileFailed", reconcileErr.Error())
+			// the status still reflects what we observed, e.g. a failed head pod
+			if err := r.updateStatus(instance); err != nil {
+				log.Error(err, "Update status error", "cluster name", request.Name)
+			}
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, reconcileErr
+		}
+	}
+
+	setCondition(instance, rayiov1alpha1.ReconcileError, metav1.ConditionFalse, "ReconcileSucceeded", "")
 	// update the status if needed
 	if err := r.updateStatus(instance); err != nil {
 		log.Error(err, "Update status error", "cluster name", request.Name)
 	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileFunc is a single step of the RayCluster reconciliation
+type reconcileFunc func(*rayiov1alpha1.RayCluster) error
+
+// reconcileFinalizer adds or removes the graceful shutdown finalizer according to the spec
+func (r *RayClusterReconciler) reconcileFinalizer(instance *rayiov1alpha1.RayCluster) error {
+	hasFinalizer := controllerutil.ContainsFinalizer(instance, common.RayClusterFinalizer)
+	if instance.Spec.GracefulShutdown != nil && !hasFinalizer {
+		controllerutil.AddFinalizer(instance, common.RayClusterFinalizer)
+	} else if instance.Spec.GracefulShutdown == nil && hasFinalizer {
+		controllerutil.RemoveFinalizer(instance, common.RayClusterFinalizer)
+	} else {
+		return nil
+	}
+	return r.Update(context.TODO(), instance)
+}
+
+// reconcileExpiration deletes or suspends the cluster once its TTL or its idle timeout expired, warning events are
+// emitted during the ExpirationWarningPeriod before. It returns when the cluster must be checked again, 0 if there is
+// no need, and true if the cluster was deleted.
+func (r *RayClusterReconciler) reconcileExpiration(instance *rayiov1alpha1.RayCluster) (time.Duration, bool, error) {
+	if utils.IsSuspended(instance) {
+		return 0, false, nil
+	}
+	var deadline time.Time
+	var cause string
+	if instance.Spec.TTLSecondsAfterCreation != nil {
+		deadline = instance.CreationTimestamp.Add(time.Duration(*instance.Spec.TTLSecondsAfterCreation) * time.Second)
+		cause = fmt.Sprintf("its TTL of %ds", *instance.Spec.TTLSecondsAfterCreation)
+	}
+	if instance.Spec.IdleTimeoutSeconds != nil && r.IdleProbe != nil {
+		idleSince, err := r.IdleProbe.IdleSince(context.TODO(), instance)
+		if err != nil {
+			// a broken probe must not expire the cluster, nor block its reconciliation
+			log.Error(err, "reconcileExpiration", "cluster name", instance.Name)
+			r.Recorder.Eventf(instance, v1.EventTypeWarning, "IdleProbeFailed", "Failed to probe the activity of the cluster: %v", err)
+		} else if idleSince != nil {
+			idleDeadline := idleSince.Add(time.Duration(*instance.Spec.IdleTimeoutSeconds) * time.Second)
+			if deadline.IsZero() || idleDeadline.Before(deadline) {
+				deadline = idleDeadline
+				cause = fmt.Sprintf("its idle timeout of %ds", *instance.Spec.IdleTimeoutSeconds)
+			}
+		}
+	}
+	if deadline.IsZero() {
+		return r.nextIdleProbe(instance, 0), false, nil
+	}
+
+	action := "deleted"
+	if instance.Spec.ExpirationAction == rayiov1alpha1.SuspendExpirationAction {
+		action = "suspended"
+	}
+	remaining := time.Until(deadline)
+	if remaining > 0 {
+		if remaining > r.ExpirationWarningPeriod {
+			return r.nextIdleProbe(instance, remaining-r.ExpirationWarningPeriod), false, nil
+		}
+		// the message doesn't change between reconciliations so the recorder aggregates the events
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "Expiring", "RayCluster will be %s at %s when %s expires",
+			action, deadline.UTC().Format(time.RFC3339), cause)
+		return r.nextIdleProbe(instance, remaining), false, nil
+	}
+
+	log.Info("reconcileExpiration", "cluster name", instance.Name, "action", action, "cause", cause)
+	r.Recorder.Eventf(instance, v1.EventTypeWarning, "Expired", "RayCluster is %s, %s expired", action, cause)
+	if instance.Spec.ExpirationAction == rayiov1alpha1.SuspendExpirationAction {
+		suspend := true
+		instance.Spec.Suspend = &suspend
+		return 0, false, r.Update(context.TODO(), instance)
+	}
+	if err := r.Delete(context.TODO(), instance); err != nil {
+		return 0, false, client.IgnoreNotFound(err)
+	}
+	return 0, true, nil
+}
+
+// nextIdleProbe caps the delay before the next check of the cluster, clusters with an idle timeout
+// are probed regularly because the activity of a cluster doesn't trigger reconciliations
+func (r *RayClusterReconciler) nextIdleProbe(instance *rayiov1alpha1.RayCluster, delay time.Duration) time.Duration {
+	if instance.Spec.IdleTimeoutSeconds != nil && (delay == 0 || delay > IdleProbeInterval) {
+		return IdleProbeInterval
+	}
+	return delay
+}
+
+// reconcileShutdown deletes the workers first, then the head pod whose preStop hook drains ray,
+// and removes the finalizer once all the pods are gone.
+func (r *RayClusterReconciler) reconcileShutdown(instance *rayiov1alpha1.RayCluster) (ctrl.Result, error) {
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.WorkerNode)}
+	if err := r.List(context.TODO(), &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if len(workerPods.Items) > 0 {
+		deleted, err := r.deletePods(workerPods)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if deleted > 0 {
+			r.Recorder.Eventf(instance, v1.EventTypeNormal, "DeletingWorkers", "Deleting %d worker pods before draining the head", deleted)
+		}
+		log.Info("reconcileShutdown", "waiting for worker pods to terminate", len(workerPods.Items))
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+	}
+
+	headPods := corev1.PodList{}
+	filterLabels = client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.HeadNode)}
+	if err := r.List(context.TODO(), &headPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if len(headPods.Items) > 0 {
+		deleted, err := r.deletePods(headPods)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if deleted > 0 {
+			r.Recorder.Eventf(instance, v1.EventTypeNormal, "DrainingHead", "Deleting head pod, its preStop hook drains ray")
+		}
+		log.Info("reconcileShutdown", "waiting for head pod to terminate", len(headPods.Items))
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+	}
+
+	controllerutil.RemoveFinalizer(instance, common.RayClusterFinalizer)
+	if err := r.Update(context.TODO(), instance); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "ShutdownCompleted", "All pods are terminated, removed finalizer %s", common.RayClusterFinalizer)
 	return ctrl.Result{}, nil
 }
 
+// deletePods deletes the pods which are not terminating yet and returns how many deletions were issued
+func (r *RayClusterReconciler) deletePods(pods corev1.PodList) (int, error) {
+	deleted := 0
+	for index := range pods.Items {
+		pod := &pods.Items[index]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		log.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
+		if err := r.deletePod(pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// deletePod deletes a ray pod, the deletion is expected until the pod event handler observes it
+func (r *RayClusterReconciler) deletePod(pod *corev1.Pod) error {
+	key, tracked := podGroupKey(pod)
+	if tracked {
+		r.Expectations.ExpectDeletions(key, pod.Name)
+	}
+	if err := r.Delete(context.TODO(), pod); err != nil {
+		if tracked {
+			r.Expectations.DeletionObserved(key, pod.Name)
+		}
+		return err
+	}
+	recordPodDeleted(pod)
+	return nil
+}
+
 func (r *RayClusterReconciler) reconcileIngress(instance *rayiov1alpha1.RayCluster) error {
 	if instance.Spec.HeadGroupSpec.EnableIngress == nil || !*instance.Spec.HeadGroupSpec.EnableIngress {
+		removeCondition(instance, rayiov1alpha1.IngressReady)
 		return nil
 	}
 
 	headIngresses := networkingv1.IngressList{}
 	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name}
 	if err := r.List(context.TODO(), &headIngresses, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionUnknown, "ListFailed", err.Error())
 		return err
 	}
 
 	if headIngresses.Items != nil && len(headIngresses.Items) == 1 {
 		r.Log.Info("reconcileIngresses", "head service ingress found", headIngresses.Items[0].Name)
+		if err := r.updateHeadIngress(instance, &headIngresses.Items[0]); err != nil {
+			setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionFalse, "UpdateFailed", err.Error())
+			return err
+		}
+		setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionTrue, "IngressFound",
+			fmt.Sprintf("ingress %s exists", headIngresses.Items[0].Name))
 		return nil
 	}
 
 	if headIngresses.Items == nil || len(headIngresses.Items) == 0 {
 		ingress, err := common.BuildIngressForHeadService(*instance)
 		if err != nil {
+			r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedToBuildIngress", "Failed to build ingress %s: %v", utils.GenerateServiceName(instance.Name), err)
+			setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionFalse, "BuildFailed", err.Error())
 			return err
 		}
 
 		if err := controllerruntime.SetControllerReference(instance, ingress, r.Scheme); err != nil {
+			setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionFalse, "BuildFailed", err.Error())
 			return err
 		}
 
 		err = r.createHeadIngress(ingress, instance)
 		if err != nil {
+			setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionFalse, "CreateFailed", err.Error())
 			return err
 		}
+		setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionTrue, "IngressCreated",
+			fmt.Sprintf("ingress %s created", ingress.Name))
 	}
 
 	return nil
 }
 
+// updateHeadIngress patches the live head ingress when it drifted from the ingress section of the head group.
+func (r *RayClusterReconciler) updateHeadIngress(instance *rayiov1alpha1.RayCluster, headIngress *networkingv1.Ingress) error {
+	desired, err := common.BuildIngressForHeadService(*instance)
+	if err != nil {
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedToBuildIngress", "Failed to build ingress %s: %v", headIngress.Name, err)
+		return err
+	}
+
+	patched := headIngress.DeepCopy()
+	if !common.SyncHeadIngress(patched, desired) {
+		return nil
+	}
+	if err := r.Patch(context.TODO(), patched, client.MergeFrom(headIngress)); err != nil {
+		return err
+	}
+	log.Info("Ingress updated successfully", "ingress name", headIngress.Name)
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Updated", "Updated ingress %s", headIngress.Name)
+	return nil
+}
+
 func (r *RayClusterReconciler) reconcileServices(instance *rayiov1alpha1.RayCluster) error {
 	headServices := corev1.ServiceList{}
 	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name}
 	if err := r.List(context.TODO(), &headServices, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionUnknown, "ListFailed", err.Error())
 		return err
 	}
 
 	if headServices.Items != nil {
 		if len(headServices.Items) == 1 {
 			r.Log.Info("reconcileServices ", "head service found", headServices.Items[0].Name)
-			// TODO: compare diff and reconcile the object
-			// For example. ServiceType might be changed or port might be modified
+			if err := r.updateHeadService(instance, &headServices.Items[0]); err != nil {
+				setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionFalse, "UpdateFailed", err.Error())
+				return err
+			}
+			setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionTrue, "ServiceFound",
+				fmt.Sprintf("service %s exists", headServices.Items[0].Name))
 			return nil
 		}
 
@@ -160,6 +440,8 @@ func (r *RayClusterReconciler) reconcileServices(instance *rayiov1alpha1.RayClus
 		// We add the protection here just in case controller has race issue or user manually create service with same label.
 		if len(headServices.Items) > 1 {
 			r.Log.Info("reconcileServices ", "Duplicates head service found", len(headServices.Items))
+			setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionTrue, "DuplicateServices",
+				fmt.Sprintf("%d head services found", len(headServices.Items)))
 			return nil
 		}
 	}
@@ -168,20 +450,276 @@ func (r *RayClusterReconciler) reconcileServices(instance *rayiov1alpha1.RayClus
 	if headServices.Items == nil || len(headServices.Items) == 0 {
 		rayHeadSvc, err := common.BuildServiceForHeadPod(*instance)
 		if err != nil {
+			setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionFalse, "BuildFailed", err.Error())
 			return err
 		}
 
 		err = r.createHeadService(rayHeadSvc, instance)
 		// if the service cannot be created we return the error and requeue
 		if err != nil {
+			setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionFalse, "CreateFailed", err.Error())
+			return err
+		}
+		setCondition(instance, rayiov1alpha1.HeadServiceReady, metav1.ConditionTrue, "ServiceCreated",
+			fmt.Sprintf("service %s created", rayHeadSvc.Name))
+	}
+
+	return nil
+}
+
+// reconcileAutoscalerRBAC creates the service account, role and role binding used by the autoscaler of the head pod.
+// The service account is left to the user when the head template sets one.
+func (r *RayClusterReconciler) reconcileAutoscalerRBAC(instance *rayiov1alpha1.RayCluster) error {
+	if !common.IsAutoscalingEnabled(*instance) {
+		return nil
+	}
+
+	var objects []client.Object
+	if instance.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName == "" {
+		objects = append(objects, common.BuildServiceAccount(*instance))
+	}
+	objects = append(objects, common.BuildRole(*instance), common.BuildRoleBinding(*instance))
+	for _, object := range objects {
+		if err := r.createIfNotExists(instance, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileRedisPasswordSecret generates the redis password secret of the cluster if it is missing.
+// The password of an existing secret is never changed since the running pods use it.
+func (r *RayClusterReconciler) reconcileRedisPasswordSecret(instance *rayiov1alpha1.RayCluster) error {
+	if !common.IsRedisPasswordSecretGenerated(*instance) {
+		return nil
+	}
+	secret, err := common.BuildRedisPasswordSecret(*instance)
+	if err != nil {
+		return err
+	}
+	return r.createIfNotExists(instance, secret)
+}
+
+// reconcilePodDisruptionBudgets creates and updates the PodDisruptionBudgets of the groups which set one,
+// and deletes the ones of the groups which don't anymore
+func (r *RayClusterReconciler) reconcilePodDisruptionBudgets(instance *rayiov1alpha1.RayCluster) error {
+	budgets := policyv1beta1.PodDisruptionBudgetList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name}
+	if err := r.List(context.TODO(), &budgets, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	existing := map[string]*policyv1beta1.PodDisruptionBudget{}
+	for index := range budgets.Items {
+		if metav1.IsControlledBy(&budgets.Items[index], instance) {
+			existing[budgets.Items[index].Name] = &budgets.Items[index]
+		}
+	}
+
+	for _, desired := range common.BuildPodDisruptionBudgets(*instance) {
+		current, ok := existing[desired.Name]
+		delete(existing, desired.Name)
+		if !ok {
+			if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(context.TODO(), desired); err != nil {
+				return err
+			}
+			r.Recorder.Eventf(instance, v1.EventTypeNormal, "Created", "Created PodDisruptionBudget %s", desired.Name)
+			continue
+		}
+		if reflect.DeepEqual(current.Spec.MinAvailable, desired.Spec.MinAvailable) &&
+			reflect.DeepEqual(current.Spec.MaxUnavailable, desired.Spec.MaxUnavailable) &&
+			reflect.DeepEqual(current.Spec.Selector, desired.Spec.Selector) {
+			continue
+		}
+		current.Spec.MinAvailable = desired.Spec.MinAvailable
+		current.Spec.MaxUnavailable = desired.Spec.MaxUnavailable
+		current.Spec.Selector = desired.Spec.Selector
+		if err := r.Update(context.TODO(), current); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Updated", "Updated PodDisruptionBudget %s", current.Name)
+	}
+
+	for _, budget := range existing {
+		if err := r.Delete(context.TODO(), budget); err != nil {
+			if errors.IsNotFound(err) {
+				// someone else deleted it
+				continue
+			}
+			return err
+		}
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted PodDisruptionBudget %s", budget.Name)
+	}
+	return nil
+}
+
+// reconcileNetworkPolicy creates and updates the NetworkPolicy of a cluster which sets one,
+// and deletes it once the field is removed
+func (r *RayClusterReconciler) reconcileNetworkPolicy(instance *rayiov1alpha1.RayCluster) error {
+	current := &networkingv1.NetworkPolicy{}
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GenerateNetworkPolicyName(instance.Name)}
+	err := r.Get(context.TODO(), key, current)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(current, instance) {
+		// a NetworkPolicy of someone else is left alone, the pods are reconciled without it
+		if instance.Spec.NetworkPolicy != nil {
+			r.Recorder.Eventf(instance, v1.EventTypeWarning, "NetworkPolicyConflict",
+				"NetworkPolicy %s already exists and is not controlled by the cluster", key.Name)
+		}
+		return nil
+	}
+
+	if instance.Spec.NetworkPolicy == nil {
+		if !exists {
+			return nil
+		}
+		if err := r.Delete(context.TODO(), current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted NetworkPolicy %s", current.Name)
+		return nil
+	}
+
+	desired, err := common.BuildNetworkPolicy(*instance)
+	if err != nil {
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedToBuildNetworkPolicy", "Failed to build NetworkPolicy %s: %v", key.Name, err)
+		return err
+	}
+	if !exists {
+		if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(context.TODO(), desired); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Created", "Created NetworkPolicy %s", desired.Name)
+		return nil
+	}
+	if reflect.DeepEqual(current.Spec, desired.Spec) {
+		return nil
+	}
+	current.Spec = desired.Spec
+	if err := r.Update(context.TODO(), current); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Updated", "Updated NetworkPolicy %s", current.Name)
+	return nil
+}
+
+// reconcilePodGroup creates the PodGroup of a cluster with a batch scheduler and keeps its spec up to date.
+// The PodGroups are not watched, their CRDs may not be installed.
+func (r *RayClusterReconciler) reconcilePodGroup(instance *rayiov1alpha1.RayCluster) error {
+	scheduler, err := batchscheduler.GetScheduler(instance)
+	if err != nil || scheduler == nil {
+		return err
+	}
+	desired := scheduler.BuildPodGroup(instance)
+	if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(desired.GroupVersionKind())
+	err = r.Get(context.TODO(), types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, current)
+	if errors.IsNotFound(err) {
+		if err := r.Create(context.TODO(), desired); err != nil {
+			r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedToCreatePodGroup", "Failed to create %s PodGroup %s: %v", scheduler.Name(), desired.GetName(), err)
 			return err
 		}
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Created", "Created %s PodGroup %s", scheduler.Name(), desired.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// only the fields set by the operator are compared, the scheduler may default others
+	spec, _, _ := unstructured.NestedMap(current.Object, "spec")
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	updated := false
+	for key, value := range desired.Object["spec"].(map[string]interface{}) {
+		if !reflect.DeepEqual(spec[key], value) {
+			spec[key] = value
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	if err := unstructured.SetNestedMap(current.Object, spec, "spec"); err != nil {
+		return err
+	}
+	if err := r.Update(context.TODO(), current); err != nil {
+		return err
+	}
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Updated", "Updated %s PodGroup %s", scheduler.Name(), desired.GetName())
+	return nil
+}
+
+// createIfNotExists creates an object owned by the cluster unless an object with the same name already exists
+func (r *RayClusterReconciler) createIfNotExists(instance *rayiov1alpha1.RayCluster, object client.Object) error {
+	kind := reflect.TypeOf(object).Elem().Name()
+	existing := object.DeepCopyObject().(client.Object)
+	if err := r.Get(context.TODO(), client.ObjectKeyFromObject(object), existing); err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(instance, object, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(context.TODO(), object); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
 	}
+	log.Info("createIfNotExists", "created", kind, "name", object.GetName())
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Created", "Created %s %s", strings.ToLower(kind), object.GetName())
+	return nil
+}
 
+// updateHeadService patches the live head service when it drifted from the desired one,
+// updates rejected by the API server are reported as events.
+func (r *RayClusterReconciler) updateHeadService(instance *rayiov1alpha1.RayCluster, headService *corev1.Service) error {
+	desired, err := common.BuildServiceForHeadPod(*instance)
+	if err != nil {
+		return err
+	}
+	if desired.Spec.Type == corev1.ServiceTypeExternalName {
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "UnsupportedServiceUpdate",
+			"Service %s can't be changed to type %s", headService.Name, desired.Spec.Type)
+		return nil
+	}
+
+	patched := headService.DeepCopy()
+	if !common.SyncHeadService(patched, desired) {
+		return nil
+	}
+	if err := r.Patch(context.TODO(), patched, client.MergeFrom(headService)); err != nil {
+		if errors.IsInvalid(err) {
+			r.Recorder.Eventf(instance, v1.EventTypeWarning, "UnsupportedServiceUpdate",
+				"Service %s can't be updated in place: %v", headService.Name, err)
+			return nil
+		}
+		return err
+	}
+	log.Info("Pod Service updated successfully", "service name", headService.Name)
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Updated", "Updated service %s", headService.Name)
 	return nil
 }
 
 func (r *RayClusterReconciler) reconcilePods(instance *rayiov1alpha1.RayCluster) error {
+	if utils.IsSuspended(instance) {
+		return r.suspendPods(instance)
+	}
 	// check if all the pods exist
 	headPods := corev1.PodList{}
 	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.HeadNode)}
@@ -189,22 +727,45 @@ func (r *RayClusterReconciler) reconcilePods(instance *rayiov1alpha1.RayCluster)
 		return err
 	}
 	// Reconcile head Pod
-	if len(headPods.Items) == 1 {
+	headReady := false
+	if !r.Expectations.SatisfiedExpectations(headExpectationsKey(instance)) {
+		// the head pod was created or deleted but the cache doesn't reflect it yet
+		log.Info("reconcilePods", "waiting for the cache to observe the head pod of cluster", instance.Name)
+		setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodPending", "waiting for the head pod to be observed")
+	} else if len(headPods.Items) == 1 {
 		headPod := headPods.Items[0]
 		log.Info("reconcilePods ", "head pod found", headPod.Name)
 		if headPod.Status.Phase == v1.PodRunning || headPod.Status.Phase == v1.PodPending {
 			log.Info("reconcilePods", "head pod is up and running... checking workers", headPod.Name)
+			if err := r.updateHeadPod(instance, headPod); err != nil {
+				return err
+			}
+			if headPod.Status.Phase == v1.PodRunning && utils.IsRayContainerReady(&headPod) {
+				headReady = true
+				setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionTrue, "HeadPodRunning",
+					fmt.Sprintf("head pod %s is running and ready", headPod.Name))
+			} else {
+				setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodNotReady",
+					fmt.Sprintf("head pod %s is %s and not ready yet", headPod.Name, headPod.Status.Phase))
+			}
+		} else if headPod.Status.Phase == v1.PodFailed || headPod.Status.Phase == v1.PodSucceeded {
+			if err := r.recoverHeadPod(instance, headPod); err != nil {
+				return err
+			}
 		} else {
+			setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodTerminated",
+				fmt.Sprintf("head pod %s is in %s phase", headPod.Name, headPod.Status.Phase))
 			return fmt.Errorf("head pod %s is not running nor pending", headPod.Name)
 		}
-	}
-	if len(headPods.Items) == 0 || headPods.Items == nil {
+	} else if len(headPods.Items) == 0 {
 		// create head pod
 		log.Info("reconcilePods ", "creating head pod for cluster", instance.Name)
 		if err := r.createHeadPod(*instance); err != nil {
+			setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "CreateFailed", err.Error())
 			return err
 		}
-	} else if len(headPods.Items) > 1 {
+		setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodCreated", "head pod created")
+	} else {
 		log.Info("reconcilePods ", "more than 1 head pod found for cluster", instance.Name)
 		itemLength := len(headPods.Items)
 		for index := 0; index < itemLength; index++ {
@@ -216,36 +777,92 @@ func (r *RayClusterReconciler) reconcilePods(instance *rayiov1alpha1.RayCluster)
 			}
 		}
 		// delete all the extra head pod pods
-		for _, extraHeadPodToDelete := range headPods.Items {
-			if err := r.Delete(context.TODO(), &extraHeadPodToDelete); err != nil {
+		for index := range headPods.Items {
+			if err := r.deletePod(&headPods.Items[index]); err != nil {
 				return err
 			}
 		}
 	}
+	if headReady && instance.Spec.HeadGroupSpec.RestartWorkersOnRecovery && instance.Status.LastHeadRestartTime != nil {
+		if err := r.restartStaleWorkers(instance); err != nil {
+			return err
+		}
+	}
 	// Reconcile worker pods now
+	syncWorkerGroupStatuses(instance)
+	var notReadyGroups, crashLoopingGroups, backoffGroups []string
 	for index, worker := range instance.Spec.WorkerGroupSpecs {
+		groupStatus := &instance.Status.WorkerGroupStatuses[index]
 		workerPods := corev1.PodList{}
 		filterLabels = client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeGroupLabelKey: worker.GroupName}
 		if err := r.List(context.TODO(), &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
 			return err
 		}
 		runningPods := corev1.PodList{}
+		terminatedPods := corev1.PodList{}
+		readyPods := int32(0)
 		for _, aPod := range workerPods.Items {
-			if (aPod.Status.Phase == v1.PodRunning || aPod.Status.Phase == v1.PodPending) && aPod.ObjectMeta.DeletionTimestamp == nil {
+			if aPod.ObjectMeta.DeletionTimestamp != nil {
+				continue
+			}
+			if aPod.Status.Phase == v1.PodRunning || aPod.Status.Phase == v1.PodPending {
 				runningPods.Items = append(runningPods.Items, aPod)
+				if aPod.Status.Phase == v1.PodRunning {
+					readyPods++
+				}
+			} else if aPod.Status.Phase == v1.PodFailed || aPod.Status.Phase == v1.PodSucceeded {
+				terminatedPods.Items = append(terminatedPods.Items, aPod)
+			}
+		}
+		if len(terminatedPods.Items) > 0 {
+			if err := r.deleteTerminatedWorkers(instance, groupStatus, terminatedPods); err != nil {
+				return err
+			}
+		} else if utils.ExpireWorkerFailures(groupStatus, r.CrashLoopPolicy, time.Now()) {
+			r.Recorder.Eventf(instance, v1.EventTypeNormal, "CrashLoopRecovered", "Worker group %s had no failure for %s", worker.GroupName, r.CrashLoopPolicy.Window)
+		}
+		if groupStatus.CrashLooping {
+			crashLoopingGroups = append(crashLoopingGroups, worker.GroupName)
+		}
+		// a group waiting for its pods is not ready either
+		if readyPods < *worker.Replicas {
+			notReadyGroups = append(notReadyGroups, fmt.Sprintf("%s (%d/%d)", worker.GroupName, readyPods, *worker.Replicas))
+		}
+		groupKey := expectations.GroupKey(instance.Namespace, instance.Name, worker.GroupName)
+		if !r.Expectations.SatisfiedExpectations(groupKey) {
+			// the cache doesn't reflect the pods created or deleted by the previous scaling of the group yet
+			log.Info("reconcilePods", "waiting for the pods created or deleted for group", worker.GroupName)
+			continue
+		}
+		hash, err := common.GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+		if err != nil {
+			return err
+		}
+		var outdatedPods []corev1.Pod
+		for _, aPod := range runningPods.Items {
+			if isPodOutdated(aPod, hash) {
+				outdatedPods = append(outdatedPods, aPod)
+			}
+		}
+		if len(outdatedPods) > 0 {
+			// scaling is resumed once all the pods of the group are up to date
+			if err := r.updateWorkerGroup(instance, worker, runningPods, outdatedPods); err != nil {
+				return err
 			}
+			continue
 		}
 		diff := *worker.Replicas - int32(len(runningPods.Items))
 		if diff > 0 {
+			if delay := utils.GetWorkerRecreationDelay(*groupStatus, time.Now()); delay > 0 {
+				log.Info("reconcilePods", "backing off the creation of workers for group", worker.GroupName, "delay", delay)
+				backoffGroups = append(backoffGroups, fmt.Sprintf("%s (%s)", worker.GroupName, delay.Round(time.Second)))
+				continue
+			}
 			//pods need to be added
 			log.Info("reconcilePods", "add workers for group", worker.GroupName)
 			//create all workers of this group
-			var i int32
-			for i = 0; i < diff; i++ {
-				log.Info("reconcilePods", "creating worker for group", worker.GroupName, fmt.Sprintf("index %d", i), fmt.Sprintf("in total %d", diff))
-				if err := r.createWorkerPod(*instance, worker); err != nil {
-					return err
-				}
+			if err := r.createWorkerPods(instance, worker, diff); err != nil {
+				return err
 			}
 		} else if diff == 0 {
 			log.Info("reconcilePods", "all workers already exist for group", worker.GroupName)
@@ -257,11 +874,15 @@ func (r *RayClusterReconciler) reconcilePods(instance *rayiov1alpha1.RayCluster)
 				pod.Name = podsToDelete
 				pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
 				log.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
+				r.Expectations.ExpectDeletions(groupKey, pod.Name)
 				if err := r.Delete(context.TODO(), &pod); err != nil {
+					r.Expectations.DeletionObserved(groupKey, pod.Name)
 					if !errors.IsNotFound(err) {
 						return err
 					}
 					log.Info("reconcilePods", "workers specified to delete was already deleted ", pod.Name)
+				} else {
+					metrics.RecordPodDeleted(pod.Namespace, rayiov1alpha1.WorkerNode, worker.GroupName)
 				}
 				r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted pod %s", pod.Name)
 			}
@@ -278,49 +899,308 @@ func (r *RayClusterReconciler) reconcilePods(instance *rayiov1alpha1.RayCluster)
 				pod.Name = podsToDelete
 				pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
 				log.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
+				r.Expectations.ExpectDeletions(groupKey, pod.Name)
 				if err := r.Delete(context.TODO(), &pod); err != nil {
+					r.Expectations.DeletionObserved(groupKey, pod.Name)
 					if !errors.IsNotFound(err) {
 						return err
 					}
 					log.Info("reconcilePods", "workers specified to delete was already deleted ", pod.Name)
+				} else {
+					metrics.RecordPodDeleted(pod.Namespace, rayiov1alpha1.WorkerNode, worker.GroupName)
 				}
 				r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted pod %s", pod.Name)
 			}
 			instance.Spec.WorkerGroupSpecs[index].ScaleStrategy.WorkersToDelete = []string{}
 
-			// remove the remaining pods not part of the scaleStrategy
-			i := 0
+			// remove the remaining pods not part of the scaleStrategy, following its scale down policy
 			if int(randomlyRemovedWorkers) > 0 {
-				for _, randomPodToDelete := range runningPods.Items {
+				var candidates []corev1.Pod
+				for _, aPod := range runningPods.Items {
 					found := false
 					for _, podsToDelete := range worker.ScaleStrategy.WorkersToDelete {
-						if randomPodToDelete.Name == podsToDelete {
+						if aPod.Name == podsToDelete {
 							found = true
 							break
 						}
 					}
 					if !found {
-						log.Info("Randomly deleting pod ", "index ", i, "/", randomlyRemovedWorkers, "with name", randomPodToDelete.Name)
-						if err := r.Delete(context.TODO(), &randomPodToDelete); err != nil {
-							if !errors.IsNotFound(err) {
-								return err
-							}
-							log.Info("reconcilePods", "workers specified to delete was already deleted ", randomPodToDelete.Name)
-						}
-						r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted pod %s", randomPodToDelete.Name)
-						// increment the number of deleted pods
-						i++
-						if i >= int(randomlyRemovedWorkers) {
-							break
+						candidates = append(candidates, aPod)
+					}
+				}
+				podsToDelete := scaledown.SelectPodsToDelete(candidates, int(randomlyRemovedWorkers), worker.ScaleStrategy.ScaleDownPolicy)
+				for i := range podsToDelete {
+					podToDelete := &podsToDelete[i]
+					log.Info("Deleting pod", "index", i, "total", randomlyRemovedWorkers, "policy", worker.ScaleStrategy.ScaleDownPolicy, "name", podToDelete.Name)
+					r.Expectations.ExpectDeletions(groupKey, podToDelete.Name)
+					if err := r.Delete(context.TODO(), podToDelete); err != nil {
+						r.Expectations.DeletionObserved(groupKey, podToDelete.Name)
+						if !errors.IsNotFound(err) {
+							return err
 						}
+						log.Info("reconcilePods", "workers specified to delete was already deleted ", podToDelete.Name)
+					} else {
+						recordPodDeleted(podToDelete)
 					}
+					r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted pod %s", podToDelete.Name)
 				}
 			}
 		}
 	}
+
+	if len(crashLoopingGroups) > 0 {
+		setCondition(instance, rayiov1alpha1.WorkersReady, metav1.ConditionFalse, "WorkersCrashLooping",
+			fmt.Sprintf("worker groups crash looping: %s", strings.Join(crashLoopingGroups, ", ")))
+	} else if len(notReadyGroups) > 0 {
+		setCondition(instance, rayiov1alpha1.WorkersReady, metav1.ConditionFalse, "WorkersNotRunning",
+			fmt.Sprintf("worker groups without enough running pods: %s", strings.Join(notReadyGroups, ", ")))
+	} else {
+		setCondition(instance, rayiov1alpha1.WorkersReady, metav1.ConditionTrue, "AllWorkersRunning", "all worker groups have the desired running pods")
+	}
+	if len(backoffGroups) > 0 {
+		// requeue until the backoff expires
+		return fmt.Errorf("backing off the creation of workers for groups: %s", strings.Join(backoffGroups, ", "))
+	}
+	return nil
+}
+
+// syncWorkerGroupStatuses keeps one status per worker group of the spec, in the same order
+func syncWorkerGroupStatuses(instance *rayiov1alpha1.RayCluster) {
+	statuses := make([]rayiov1alpha1.WorkerGroupStatus, 0, len(instance.Spec.WorkerGroupSpecs))
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		groupStatus := rayiov1alpha1.WorkerGroupStatus{GroupName: worker.GroupName}
+		for _, existing := range instance.Status.WorkerGroupStatuses {
+			if existing.GroupName == worker.GroupName {
+				groupStatus = existing
+				break
+			}
+		}
+		statuses = append(statuses, groupStatus)
+	}
+	instance.Status.WorkerGroupStatuses = statuses
+}
+
+// deleteTerminatedWorkers garbage-collects the workers of a group which failed or succeeded, the failed ones are
+// counted as failures
+func (r *RayClusterReconciler) deleteTerminatedWorkers(instance *rayiov1alpha1.RayCluster, groupStatus *rayiov1alpha1.WorkerGroupStatus, terminatedPods corev1.PodList) error {
+	failedPods := corev1.PodList{}
+	succeededPods := corev1.PodList{}
+	for _, aPod := range terminatedPods.Items {
+		if aPod.Status.Phase == v1.PodFailed {
+			failedPods.Items = append(failedPods.Items, aPod)
+		} else {
+			succeededPods.Items = append(succeededPods.Items, aPod)
+		}
+	}
+	failed, err := r.deletePods(failedPods)
+	succeeded := 0
+	if err == nil {
+		succeeded, err = r.deletePods(succeededPods)
+	}
+	if deleted := failed + succeeded; deleted > 0 {
+		log.Info("deleteTerminatedWorkers", "group", groupStatus.GroupName, "failed", failed, "succeeded", succeeded)
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "WorkersTerminated", "Deleted %d terminated workers of group %s", deleted, groupStatus.GroupName)
+	}
+	if failed > 0 && utils.RecordWorkerFailures(groupStatus, int32(failed), r.CrashLoopPolicy, time.Now()) {
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "CrashLooping", "Worker group %s had %d failures within %s",
+			groupStatus.GroupName, groupStatus.RecentFailures, r.CrashLoopPolicy.Window)
+	}
+	return err
+}
+
+// recoverHeadPod deletes a head pod which terminated, waiting for the backoff since the previous recovery.
+// The head is recreated by the next reconciliation.
+func (r *RayClusterReconciler) recoverHeadPod(instance *rayiov1alpha1.RayCluster, headPod corev1.Pod) error {
+	if headPod.DeletionTimestamp != nil {
+		setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodRecovering",
+			fmt.Sprintf("head pod %s is being deleted", headPod.Name))
+		return nil
+	}
+	if delay := utils.GetHeadRecoveryDelay(instance.Status, time.Now()); delay > 0 {
+		setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodBackoff",
+			fmt.Sprintf("head pod %s is in %s phase, it is recreated in %s", headPod.Name, headPod.Status.Phase, delay.Round(time.Second)))
+		return fmt.Errorf("head pod %s is in %s phase, backing off %s before recreating it", headPod.Name, headPod.Status.Phase, delay.Round(time.Second))
+	}
+
+	log.Info("recoverHeadPod", "deleting terminated head pod", headPod.Name, "phase", headPod.Status.Phase)
+	if err := r.deletePod(&headPod); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	now := metav1.Now()
+	instance.Status.HeadRestarts++
+	instance.Status.LastHeadRestartTime = &now
+	r.Recorder.Eventf(instance, v1.EventTypeWarning, "RecoveringHead", "Deleted head pod %s in %s phase, restart %d",
+		headPod.Name, headPod.Status.Phase, instance.Status.HeadRestarts)
+	setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "HeadPodRecovering",
+		fmt.Sprintf("head pod %s was in %s phase, recreating it", headPod.Name, headPod.Status.Phase))
+	return nil
+}
+
+// restartStaleWorkers deletes the workers created before the last recovery of the head,
+// they are recreated by reconcilePods and connect to the new GCS
+func (r *RayClusterReconciler) restartStaleWorkers(instance *rayiov1alpha1.RayCluster) error {
+	workerPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.WorkerNode)}
+	if err := r.List(context.TODO(), &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+	stalePods := corev1.PodList{}
+	for _, aPod := range workerPods.Items {
+		if aPod.CreationTimestamp.Before(instance.Status.LastHeadRestartTime) {
+			stalePods.Items = append(stalePods.Items, aPod)
+		}
+	}
+	deleted, err := r.deletePods(stalePods)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "RestartingWorkers", "Deleted %d workers created before the recovery of the head pod", deleted)
+	}
+	return nil
+}
+
+// updateHeadPod deletes the head pod if it was built from an outdated spec, it is then recreated by reconcilePods
+func (r *RayClusterReconciler) updateHeadPod(instance *rayiov1alpha1.RayCluster, headPod corev1.Pod) error {
+	hash, err := common.GenerateHeadPodTemplateHash(*instance)
+	if err != nil {
+		return err
+	}
+	if !isPodOutdated(headPod, hash) {
+		return nil
+	}
+	log.Info("updateHeadPod", "deleting outdated head pod", headPod.Name)
+	if err := r.deletePod(&headPod); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "UpdatingHead", "Deleted outdated head pod %s", headPod.Name)
+	return nil
+}
+
+// updateWorkerGroup replaces the outdated pods of a worker group following its UpdateStrategy
+func (r *RayClusterReconciler) updateWorkerGroup(instance *rayiov1alpha1.RayCluster, worker rayiov1alpha1.WorkerGroupSpec, runningPods corev1.PodList, outdatedPods []corev1.Pod) error {
+	if worker.UpdateStrategy.Type == rayiov1alpha1.RecreateUpdateStrategyType {
+		log.Info("updateWorkerGroup", "recreating all the outdated pods of group", worker.GroupName)
+		for index := range outdatedPods {
+			if err := r.deleteOutdatedWorker(instance, outdatedPods[index]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	maxSurge, maxUnavailable, err := utils.GetRollingUpdateLimits(worker)
+	if err != nil {
+		return err
+	}
+	available := int32(0)
+	for _, aPod := range runningPods.Items {
+		if aPod.Status.Phase == v1.PodRunning {
+			available++
+		}
+	}
+	total := int32(len(runningPods.Items))
+	updated := total - int32(len(outdatedPods))
+	toCreate, toDelete := utils.CalculateRollingUpdate(*worker.Replicas, total, updated, available, maxSurge, maxUnavailable)
+	log.Info("updateWorkerGroup", "group", worker.GroupName, "outdated", len(outdatedPods), "toCreate", toCreate, "toDelete", toDelete)
+
+	if err := r.createWorkerPods(instance, worker, toCreate); err != nil {
+		return err
+	}
+	// outdated pods which are not running don't count as available and are always replaced first
+	sort.SliceStable(outdatedPods, func(i, j int) bool {
+		return outdatedPods[i].Status.Phase != v1.PodRunning && outdatedPods[j].Status.Phase == v1.PodRunning
+	})
+	for index := range outdatedPods {
+		if outdatedPods[index].Status.Phase == v1.PodRunning {
+			if toDelete <= 0 {
+				break
+			}
+			toDelete--
+		}
+		if err := r.deleteOutdatedWorker(instance, outdatedPods[index]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RayClusterReconciler) deleteOutdatedWorker(instance *rayiov1alpha1.RayCluster, pod corev1.Pod) error {
+	log.Info("Deleting outdated pod", "namespace", pod.Namespace, "name", pod.Name)
+	groupKey := expectations.GroupKey(pod.Namespace, instance.Name, pod.Labels[common.RayNodeGroupLabelKey])
+	r.Expectations.ExpectDeletions(groupKey, pod.Name)
+	if err := r.Delete(context.TODO(), &pod); err != nil {
+		r.Expectations.DeletionObserved(groupKey, pod.Name)
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		log.Info("reconcilePods", "outdated worker was already deleted", pod.Name)
+	} else {
+		recordPodDeleted(&pod)
+	}
+	r.Recorder.Eventf(instance, v1.EventTypeNormal, "Deleted", "Deleted outdated pod %s", pod.Name)
+	return nil
+}
+
+// suspendPods deletes all the pods of a suspended cluster, the services and the ingress are kept
+func (r *RayClusterReconciler) suspendPods(instance *rayiov1alpha1.RayCluster) error {
+	pods := corev1.PodList{}
+	if err := r.List(context.TODO(), &pods, client.InNamespace(instance.Namespace), client.MatchingLabels{common.RayClusterLabelKey: instance.Name}); err != nil {
+		return err
+	}
+	deleted := 0
+	for index := range pods.Items {
+		pod := &pods.Items[index]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		log.Info("suspendPods", "deleting pod", pod.Name)
+		if err := r.deletePod(pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "Suspended", "Deleted %d pods of the suspended cluster", deleted)
+	}
+	setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionFalse, "Suspended", "the cluster is suspended")
+	setCondition(instance, rayiov1alpha1.WorkersReady, metav1.ConditionFalse, "Suspended", "the cluster is suspended")
 	return nil
 }
 
+// recordPodDeleted counts the deletion of a ray pod using its labels
+func recordPodDeleted(pod *corev1.Pod) {
+	metrics.RecordPodDeleted(pod.Namespace, rayiov1alpha1.RayNodeType(pod.Labels[common.RayNodeTypeLabelKey]), pod.Labels[common.RayNodeGroupLabelKey])
+}
+
+// headExpectationsKey returns the expectations key of the head pod
+func headExpectationsKey(instance *rayiov1alpha1.RayCluster) string {
+	return expectations.HeadKey(instance.Namespace, instance.Name)
+}
+
+// setCondition sets the condition of the given type on the cluster status, LastTransitionTime only changes with the status
+func setCondition(instance *rayiov1alpha1.RayCluster, conditionType rayiov1alpha1.RayClusterConditionType, status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: instance.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// removeCondition removes the condition of the given type from the cluster status.
+// RemoveStatusCondition of apimachinery 0.19 panics on an empty list, e.g. a status written with conditions: [].
+func removeCondition(instance *rayiov1alpha1.RayCluster, conditionType rayiov1alpha1.RayClusterConditionType) {
+	if meta.FindStatusCondition(instance.Status.Conditions, string(conditionType)) == nil {
+		return
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, string(conditionType))
+}
+
 func (r *RayClusterReconciler) createHeadIngress(ingress *networkingv1.Ingress, instance *rayiov1alpha1.RayCluster) error {
 	// making sure the name is valid
 	ingress.Name = utils.CheckName(ingress.Name)
@@ -363,6 +1243,14 @@ func (r *RayClusterReconciler) createHeadService(rayHeadSvc *v1.Service, instanc
 }
 
 func (r *RayClusterReconciler) createHeadPod(instance rayiov1alpha1.RayCluster) error {
+	if len(instance.Spec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+		r.Recorder.Eventf(&instance, v1.EventTypeWarning, "FailedCreate", "Head group of %s has no container", instance.Name)
+		return fmt.Errorf("the head group of %s has no container", instance.Name)
+	}
+	// the ports ray listens on win over the container ports, the user is told about the ones fixed
+	for _, conflict := range common.GetHeadPortConflicts(instance) {
+		r.Recorder.Eventf(&instance, v1.EventTypeWarning, "PortMismatch", "Head group of %s: %s", instance.Name, conflict)
+	}
 	// build the pod then create it
 	pod := r.buildHeadPod(instance)
 	podIdentifier := types.NamespacedName{
@@ -371,7 +1259,10 @@ func (r *RayClusterReconciler) createHeadPod(instance rayiov1alpha1.RayCluster)
 	}
 
 	log.Info("createHeadPod", "head pod with name", pod.GenerateName)
+	r.Expectations.ExpectCreations(headExpectationsKey(&instance), 1)
 	if err := r.Create(context.TODO(), &pod); err != nil {
+		// the creation won't be observed
+		r.Expectations.CreationObserved(headExpectationsKey(&instance))
 		if errors.IsAlreadyExists(err) {
 			fetchedPod := corev1.Pod{}
 			// the pod might be in terminating state, we need to check
@@ -383,13 +1274,46 @@ func (r *RayClusterReconciler) createHeadPod(instance rayiov1alpha1.RayCluster)
 			}
 			log.Info("Creating pod", "Pod already exists", pod.Name)
 		} else {
+			metrics.RecordPodFailed(pod.Namespace, rayiov1alpha1.HeadNode, pod.Labels[common.RayNodeGroupLabelKey])
 			return err
 		}
+	} else {
+		metrics.RecordPodCreated(pod.Namespace, rayiov1alpha1.HeadNode, pod.Labels[common.RayNodeGroupLabelKey])
 	}
 	r.Recorder.Eventf(&instance, v1.EventTypeNormal, "Created", "Created head pod %s", pod.Name)
 	return nil
 }
 
+// createWorkerPods creates count workers of the group in batches of growing size, the next scaling of the group
+// waits until the cache has them. The creation errors are summarized in a single event.
+func (r *RayClusterReconciler) createWorkerPods(instance *rayiov1alpha1.RayCluster, worker rayiov1alpha1.WorkerGroupSpec, count int32) error {
+	if count <= 0 {
+		return nil
+	}
+	if len(worker.Template.Spec.Containers) == 0 {
+		r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedCreate", "Worker group %s has no container", worker.GroupName)
+		return fmt.Errorf("the worker group %s of %s has no container", worker.GroupName, instance.Name)
+	}
+	groupKey := expectations.GroupKey(instance.Namespace, instance.Name, worker.GroupName)
+	r.Expectations.ExpectCreations(groupKey, int(count))
+	log.Info("createWorkerPods", "group", worker.GroupName, "count", count, "max batch size", r.MaxCreationBatchSize)
+	successes, errs := utils.SlowStartBatch(int(count), r.MaxCreationBatchSize, func() error {
+		return r.createWorkerPod(*instance, worker)
+	})
+	// the pods of the skipped batches won't be created
+	for i := successes + len(errs); i < int(count); i++ {
+		r.Expectations.CreationObserved(groupKey)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	err := utilerrors.NewAggregate(errs)
+	r.Recorder.Eventf(instance, v1.EventTypeWarning, "FailedCreate", "Created %d of %d workers of group %s: %v",
+		successes, count, worker.GroupName, err)
+	return err
+}
+
+// createWorkerPod creates a worker of the group, it must be expected by the caller
 func (r *RayClusterReconciler) createWorkerPod(instance rayiov1alpha1.RayCluster, worker rayiov1alpha1.WorkerGroupSpec) error {
 	// build the pod then create it
 	pod := r.buildWorkerPod(instance, worker)
@@ -400,6 +1324,8 @@ func (r *RayClusterReconciler) createWorkerPod(instance rayiov1alpha1.RayCluster
 	replica := corev1.Pod{}
 	replica = pod
 	if err := r.Create(context.TODO(), &replica); err != nil {
+		// the creation won't be observed
+		r.Expectations.CreationObserved(expectations.GroupKey(instance.Namespace, instance.Name, worker.GroupName))
 		if errors.IsAlreadyExists(err) {
 			fetchedPod := corev1.Pod{}
 			// the pod might be in terminating state, we need to check
@@ -412,8 +1338,11 @@ func (r *RayClusterReconciler) createWorkerPod(instance rayiov1alpha1.RayCluster
 			log.Info("Creating pod", "Pod already exists", pod.Name)
 		} else {
 			log.Error(fmt.Errorf("createWorkerPod error"), "error creating pod", "pod", pod, "err = ", err)
+			metrics.RecordPodFailed(pod.Namespace, rayiov1alpha1.WorkerNode, worker.GroupName)
 			return err
 		}
+	} else {
+		metrics.RecordPodCreated(pod.Namespace, rayiov1alpha1.WorkerNode, worker.GroupName)
 	}
 	log.Info("Created pod", "Pod ", pod.GenerateName)
 	r.Recorder.Eventf(&instance, v1.EventTypeNormal, "Created", "Created worker pod %s", pod.Name)
@@ -425,8 +1354,20 @@ func (r *RayClusterReconciler) buildHeadPod(instance rayiov1alpha1.RayCluster) c
 	podName := strings.ToLower(instance.Name + common.DashSymbol + string(rayiov1alpha1.HeadNode) + common.DashSymbol)
 	podName = utils.CheckName(podName) // making sure the name is valid
 	svcName := utils.GenerateServiceName(instance.Name)
+	// the hash is computed before DefaultHeadPodTemplate completes the ray start params of the copy
+	instance = *instance.DeepCopy()
+	hash, err := common.GenerateHeadPodTemplateHash(instance)
+	if err != nil {
+		log.Error(err, "Failed to generate template hash for raycluster pod")
+	}
+	if instance.Spec.HeadGroupSpec.RayStartParams == nil {
+		instance.Spec.HeadGroupSpec.RayStartParams = map[string]string{}
+	}
 	podConf := common.DefaultHeadPodTemplate(instance, instance.Spec.HeadGroupSpec, podName, svcName)
-	pod := common.BuildPod(podConf, rayiov1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, svcName)
+	pod := common.BuildPod(podConf, rayiov1alpha1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, svcName, instance.Spec.EnableInTreeAutoscaling)
+	if err == nil {
+		setPodTemplateHash(&pod, hash)
+	}
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		log.Error(err, "Failed to set controller reference for raycluster pod")
@@ -440,8 +1381,20 @@ func (r *RayClusterReconciler) buildWorkerPod(instance rayiov1alpha1.RayCluster,
 	podName := strings.ToLower(instance.Name + common.DashSymbol + string(rayiov1alpha1.WorkerNode) + common.DashSymbol + worker.GroupName + common.DashSymbol)
 	podName = utils.CheckName(podName) // making sure the name is valid
 	svcName := utils.GenerateServiceName(instance.Name)
+	// the hash is computed before DefaultWorkerPodTemplate completes the ray start params of the copy
+	worker = *worker.DeepCopy()
+	hash, err := common.GeneratePodTemplateHash(worker.Template, worker.RayStartParams)
+	if err != nil {
+		log.Error(err, "Failed to generate template hash for raycluster pod")
+	}
+	if worker.RayStartParams == nil {
+		worker.RayStartParams = map[string]string{}
+	}
 	podTemplateSpec := common.DefaultWorkerPodTemplate(instance, worker, podName, svcName)
-	pod := common.BuildPod(podTemplateSpec, rayiov1alpha1.WorkerNode, worker.RayStartParams, svcName)
+	pod := common.BuildPod(podTemplateSpec, rayiov1alpha1.WorkerNode, worker.RayStartParams, svcName, instance.Spec.EnableInTreeAutoscaling)
+	if err == nil {
+		setPodTemplateHash(&pod, hash)
+	}
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		log.Error(err, "Failed to set controller reference for raycluster pod")
@@ -450,30 +1403,56 @@ func (r *RayClusterReconciler) buildWorkerPod(instance rayiov1alpha1.RayCluster,
 	return pod
 }
 
+// setPodTemplateHash annotates the pod with the hash of the group spec it was built from
+func setPodTemplateHash(pod *corev1.Pod, hash string) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[common.RayPodTemplateHashKey] = hash
+}
+
+// isPodOutdated returns true if the pod was built from another version of the group spec.
+// Pods created before the hash was introduced can't be compared and are never considered outdated.
+func isPodOutdated(pod corev1.Pod, hash string) bool {
+	podHash, ok := pod.Annotations[common.RayPodTemplateHashKey]
+	return ok && podHash != hash
+}
+
 // SetupWithManager builds the reconciler.
 func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&rayiov1alpha1.RayCluster{}).Named("raycluster-controller").
-		Watches(&source.Kind{Type: &corev1.Pod{}}, &handler.EnqueueRequestForOwner{
-			IsController: true,
-			OwnerType:    &rayiov1alpha1.RayCluster{},
+		Watches(&source.Kind{Type: &corev1.Pod{}}, &podEventHandler{
+			EnqueueRequestForOwner: &handler.EnqueueRequestForOwner{
+				IsController: true,
+				OwnerType:    &rayiov1alpha1.RayCluster{},
+			},
+			expectations: r.Expectations,
 		}).
 		Watches(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{
 			IsController: true,
 			OwnerType:    &rayiov1alpha1.RayCluster{},
 		}).
+		Owns(&policyv1beta1.PodDisruptionBudget{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		WithOptions(controller.Options{MaxConcurrentReconciles: reconcileConcurrency}).
 		Complete(r)
 }
 
 func (r *RayClusterReconciler) updateStatus(instance *rayiov1alpha1.RayCluster) error {
-	runtimePods := corev1.PodList{}
-	filterLabels := client.MatchingLabels{"rayClusterName": instance.Name}
-	if err := r.List(context.TODO(), &runtimePods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+	headPods := corev1.PodList{}
+	filterLabels := client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.HeadNode)}
+	if err := r.List(context.TODO(), &headPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
+		return err
+	}
+
+	workerPods := corev1.PodList{}
+	filterLabels = client.MatchingLabels{common.RayClusterLabelKey: instance.Name, common.RayNodeTypeLabelKey: string(rayiov1alpha1.WorkerNode)}
+	if err := r.List(context.TODO(), &workerPods, client.InNamespace(instance.Namespace), filterLabels); err != nil {
 		return err
 	}
 
-	count := utils.CalculateAvailableReplicas(runtimePods)
+	count := utils.CalculateAvailableReplicas(workerPods)
 	if instance.Status.AvailableWorkerReplicas != count {
 		instance.Status.AvailableWorkerReplicas = count
 	}
@@ -493,7 +1472,35 @@ func (r *RayClusterReconciler) updateStatus(instance *rayiov1alpha1.RayCluster)
 		instance.Status.MaxWorkerReplicas = count
 	}
 
-	// TODO (@Jeffwan): Update state field later.
+	var headPod *corev1.Pod
+	for index := range headPods.Items {
+		if headPods.Items[index].DeletionTimestamp == nil {
+			headPod = &headPods.Items[index]
+			break
+		}
+	}
+	state, reason := utils.CalculateClusterState(headPod, workerPods, instance.Status.DesiredWorkerReplicas)
+	if utils.IsSuspended(instance) {
+		state, reason = rayiov1alpha1.Suspended, "the cluster is suspended"
+	}
+	transitioned := instance.Status.State != state
+	if transitioned {
+		log.Info("updateStatus", "cluster name", instance.Name, "old state", instance.Status.State, "new state", state, "reason", reason)
+		r.Recorder.Eventf(instance, v1.EventTypeNormal, "StateChanged", "RayCluster state changed from %q to %q", instance.Status.State, state)
+		instance.Status.State = state
+		instance.Status.LastStateTransitionTime = metav1.Now()
+	}
+	// the provisioning duration is observed once per cluster, the condition remembers it across operator restarts.
+	// Clusters already ready when the condition was introduced get it without being observed.
+	if state == rayiov1alpha1.Ready && meta.FindStatusCondition(instance.Status.Conditions, string(rayiov1alpha1.Provisioned)) == nil {
+		if transitioned {
+			metrics.ObserveClusterReady(instance)
+		}
+		setCondition(instance, rayiov1alpha1.Provisioned, metav1.ConditionTrue, "Ready", "the cluster has been ready")
+	}
+	instance.Status.Reason = reason
+	metrics.UpdateClusterMetrics(instance)
+
 	// We always update instance no matter if there's one change or not.
 	instance.Status.LastUpdateTime.Time = time.Now()
 	if err := r.Status().Update(context.Background(), instance); err != nil {
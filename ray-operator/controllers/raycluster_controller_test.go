@@ -19,10 +19,12 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"testing"
 	"time"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/expectations"
 	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 
 	. "github.com/onsi/ginkgo"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	// +kubebuilder:scaffold:imports
@@ -148,6 +151,17 @@ var _ = Context("Inside the default namespace", func() {
 			Expect(svc.Spec.Selector[common.RayIDLabelKey]).Should(Equal(utils.GenerateIdentifier(myRayCluster.Name, rayiov1alpha1.HeadNode)))
 		})
 
+		It("should report the head service and head pod conditions", func() {
+			Eventually(
+				getConditionStatusFunc(ctx, myRayCluster, rayiov1alpha1.HeadServiceReady),
+				time.Second*15, time.Millisecond*500).Should(Equal(metav1.ConditionTrue), "My raycluster status = %v", myRayCluster.Status)
+			// pods are never scheduled in the test environment, so the head pod can't become ready
+			Eventually(
+				getConditionStatusFunc(ctx, myRayCluster, rayiov1alpha1.HeadPodReady),
+				time.Second*15, time.Millisecond*500).Should(Equal(metav1.ConditionFalse), "My raycluster status = %v", myRayCluster.Status)
+			Expect(myRayCluster.Status.State).Should(Equal(rayiov1alpha1.UnHealthy))
+		})
+
 		It("should create more than 1 worker", func() {
 			Eventually(
 				listResourceFunc(ctx, &workerPods, filterLabels, &client.ListOptions{Namespace: "default"}),
@@ -263,6 +277,109 @@ func listResourceFunc(ctx context.Context, workerPods *corev1.PodList, opt ...cl
 	}
 }
 
+func getConditionStatusFunc(ctx context.Context, cluster *rayiov1alpha1.RayCluster, conditionType rayiov1alpha1.RayClusterConditionType) func() (metav1.ConditionStatus, error) {
+	return func() (metav1.ConditionStatus, error) {
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}, cluster); err != nil {
+			return "", err
+		}
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, string(conditionType))
+		if condition == nil {
+			return "", nil
+		}
+		return condition.Status, nil
+	}
+}
+
+func TestRemoveCondition(t *testing.T) {
+	instance := &rayiov1alpha1.RayCluster{}
+	instance.Status.Conditions = []metav1.Condition{}
+	removeCondition(instance, rayiov1alpha1.IngressReady)
+	if len(instance.Status.Conditions) != 0 {
+		t.Fatalf("Expected no conditions, got %v", instance.Status.Conditions)
+	}
+
+	setCondition(instance, rayiov1alpha1.IngressReady, metav1.ConditionTrue, "Created", "")
+	setCondition(instance, rayiov1alpha1.HeadPodReady, metav1.ConditionTrue, "Running", "")
+	removeCondition(instance, rayiov1alpha1.IngressReady)
+	if len(instance.Status.Conditions) != 1 || instance.Status.Conditions[0].Type != string(rayiov1alpha1.HeadPodReady) {
+		t.Fatalf("Expected only the HeadPodReady condition, got %v", instance.Status.Conditions)
+	}
+}
+
+func TestUpdateHeadPodExpectsDeletion(t *testing.T) {
+	cluster := newSampleCluster()
+	headPod := newSamplePod("raycluster-sample-head", rayiov1alpha1.HeadNode, common.RayHeadGroupName)
+	headPod.Annotations = map[string]string{common.RayPodTemplateHashKey: "outdated"}
+	r := newFakeRayClusterReconciler(cluster, headPod)
+
+	if err := r.updateHeadPod(cluster, *headPod); err != nil {
+		t.Fatalf("Failed to update the head pod: %v", err)
+	}
+	if r.Expectations.SatisfiedExpectations(headExpectationsKey(cluster)) {
+		t.Fatalf("Expected the deletion of the head pod to be expected")
+	}
+	// a worker group named like the head group has its own expectations
+	if !r.Expectations.SatisfiedExpectations(expectations.GroupKey("default", cluster.Name, common.RayHeadGroupName)) {
+		t.Fatalf("Expected the expectations of the worker group to be satisfied")
+	}
+}
+
+func TestWorkersNotReadyWhileExpectationsPending(t *testing.T) {
+	r := newFakeRayClusterReconciler(
+		newSampleCluster("small-group"),
+		newSamplePod("raycluster-sample-head", rayiov1alpha1.HeadNode, common.RayHeadGroupName),
+	)
+	// the workers created by a previous reconcile are not in the cache yet
+	r.Expectations.ExpectCreations(expectations.GroupKey("default", sampleRequest.Name, "small-group"), 2)
+
+	_, cluster := reconcileSampleCluster(t, r)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayiov1alpha1.WorkersReady))
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Fatalf("Expected the workers not to be ready but got `%v`", condition)
+	}
+}
+
+func TestCreatePodsWithoutContainer(t *testing.T) {
+	headPod := newSamplePod("raycluster-sample-head", rayiov1alpha1.HeadNode, common.RayHeadGroupName)
+	tests := []struct {
+		name    string
+		cluster func() *rayiov1alpha1.RayCluster
+		pods    []client.Object
+	}{
+		{
+			name: "head group",
+			cluster: func() *rayiov1alpha1.RayCluster {
+				cluster := newSampleCluster()
+				cluster.Spec.HeadGroupSpec.Template.Spec.Containers = nil
+				return cluster
+			},
+		},
+		{
+			name: "worker group",
+			cluster: func() *rayiov1alpha1.RayCluster {
+				cluster := newSampleCluster("small-group")
+				cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers = nil
+				return cluster
+			},
+			pods: []client.Object{headPod},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newFakeRayClusterReconciler(append(tc.pods, tc.cluster())...)
+
+			if _, err := r.Reconcile(context.Background(), sampleRequest); err == nil {
+				t.Fatalf("Expected the reconcile to fail")
+			}
+			expectEvent(t, r.Recorder, "FailedCreate")
+			if pods := listSamplePods(t, r); len(pods) != len(tc.pods) {
+				t.Fatalf("Expected no pod to be created but got `%v`", pods)
+			}
+		})
+	}
+}
+
 func retryOnOldRevision(attempts int, sleep time.Duration, f func() error) error {
 	var err error
 	for i := 0; i < attempts; i++ {
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestRayClusterExpiration(t *testing.T) {
+	recentActivity := map[string]string{common.RayLastActivityAnnotationKey: time.Now().Add(-time.Minute).Format(time.RFC3339)}
+	oldActivity := map[string]string{common.RayLastActivityAnnotationKey: time.Now().Add(-90 * time.Minute).Format(time.RFC3339)}
+	tests := []struct {
+		name        string
+		ttl         *int32
+		idleTimeout *int32
+		annotations map[string]string
+		// requeue is the expected requeue, give or take a minute
+		requeue   time.Duration
+		event     string
+		deleted   bool
+		suspended bool
+	}{
+		{name: "warning before the TTL expires", ttl: pointer.Int32Ptr(3900), requeue: 5 * time.Minute, event: "Expiring"},
+		{name: "deleted once the TTL expired", ttl: pointer.Int32Ptr(3600), event: "Expired", deleted: true},
+		{name: "kept with a recent activity", idleTimeout: pointer.Int32Ptr(3600), annotations: recentActivity, requeue: IdleProbeInterval},
+		{name: "suspended once idle", idleTimeout: pointer.Int32Ptr(3600), annotations: oldActivity, event: "Expired", suspended: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newSampleCluster()
+			cluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			cluster.Annotations = tc.annotations
+			cluster.Spec.TTLSecondsAfterCreation = tc.ttl
+			cluster.Spec.IdleTimeoutSeconds = tc.idleTimeout
+			if tc.idleTimeout != nil {
+				cluster.Spec.ExpirationAction = rayiov1alpha1.SuspendExpirationAction
+			}
+			r := newFakeRayClusterReconciler(cluster)
+
+			result, current := reconcileSampleCluster(t, r)
+			if tc.requeue != 0 && (result.RequeueAfter <= tc.requeue-time.Minute || result.RequeueAfter > tc.requeue) {
+				t.Fatalf("Expected a requeue in `%v` but got `%v`", tc.requeue, result.RequeueAfter)
+			}
+			if tc.event != "" {
+				expectEvent(t, r.Recorder, tc.event)
+			}
+			if deleted := current.Name == ""; deleted != tc.deleted {
+				t.Fatalf("Expected deleted `%v` but got `%v`", tc.deleted, deleted)
+			}
+			if suspended := current.Status.State == rayiov1alpha1.Suspended; suspended != tc.suspended {
+				t.Fatalf("Expected suspended `%v` but got `%v`", tc.suspended, suspended)
+			}
+		})
+	}
+}
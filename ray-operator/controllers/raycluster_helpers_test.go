@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/expectations"
+	"github.com/ray-project/kuberay/ray-operator/controllers/idle"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// sampleRequest is the reconcile request of the cluster returned by newSampleCluster
+var sampleRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "raycluster-sample"}}
+
+// newFakeRayClusterReconciler returns a reconciler backed by a fake client holding the given objects
+func newFakeRayClusterReconciler(objects ...client.Object) *RayClusterReconciler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rayiov1alpha1.AddToScheme(scheme)
+	return &RayClusterReconciler{
+		Client:                  fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Scheme:                  scheme,
+		Log:                     ctrl.Log.WithName("controllers").WithName("RayCluster"),
+		Recorder:                record.NewFakeRecorder(100),
+		Expectations:            expectations.NewPodExpectations(),
+		MaxCreationBatchSize:    DefaultMaxCreationBatchSize,
+		IdleProbe:               &idle.AnnotationProbe{},
+		ExpirationWarningPeriod: DefaultExpirationWarningPeriod,
+		CrashLoopPolicy:         utils.DefaultCrashLoopPolicy(),
+	}
+}
+
+// newSampleCluster returns the raycluster-sample cluster with a head only, the given worker groups are added
+func newSampleCluster(workerGroupNames ...string) *rayiov1alpha1.RayCluster {
+	cluster := &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: sampleRequest.Name, Namespace: sampleRequest.Namespace},
+		Spec: rayiov1alpha1.RayClusterSpec{
+			HeadGroupSpec: rayiov1alpha1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:1.12.0"}}},
+				},
+			},
+		},
+	}
+	for _, groupName := range workerGroupNames {
+		cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, rayiov1alpha1.WorkerGroupSpec{
+			GroupName:      groupName,
+			Replicas:       pointer.Int32Ptr(2),
+			MinReplicas:    pointer.Int32Ptr(2),
+			MaxReplicas:    pointer.Int32Ptr(4),
+			RayStartParams: map[string]string{},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:1.12.0"}}},
+			},
+		})
+	}
+	return cluster
+}
+
+// newSamplePod returns a running pod of the given group of raycluster-sample
+func newSamplePod(name string, nodeType rayiov1alpha1.RayNodeType, groupName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: sampleRequest.Namespace,
+			Labels: map[string]string{
+				common.RayClusterLabelKey:   sampleRequest.Name,
+				common.RayNodeTypeLabelKey:  string(nodeType),
+				common.RayNodeGroupLabelKey: groupName,
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+// reconcileSampleCluster reconciles raycluster-sample and returns the result and the cluster it left
+func reconcileSampleCluster(t *testing.T, r *RayClusterReconciler) (ctrl.Result, *rayiov1alpha1.RayCluster) {
+	result, err := r.Reconcile(context.Background(), sampleRequest)
+	if err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	cluster := &rayiov1alpha1.RayCluster{}
+	if err := r.Get(context.Background(), sampleRequest.NamespacedName, cluster); client.IgnoreNotFound(err) != nil {
+		t.Fatalf("Failed to get the RayCluster: %v", err)
+	}
+	return result, cluster
+}
+
+// updateSampleCluster applies the change to the stored raycluster-sample
+func updateSampleCluster(t *testing.T, r *RayClusterReconciler, change func(cluster *rayiov1alpha1.RayCluster)) {
+	cluster := &rayiov1alpha1.RayCluster{}
+	if err := r.Get(context.Background(), sampleRequest.NamespacedName, cluster); err != nil {
+		t.Fatalf("Failed to get the RayCluster: %v", err)
+	}
+	change(cluster)
+	if err := r.Update(context.Background(), cluster); err != nil {
+		t.Fatalf("Failed to update the RayCluster: %v", err)
+	}
+}
+
+// listSamplePods returns the pods of the default namespace
+func listSamplePods(t *testing.T, r *RayClusterReconciler) []corev1.Pod {
+	pods := corev1.PodList{}
+	if err := r.List(context.Background(), &pods, client.InNamespace(sampleRequest.Namespace)); err != nil {
+		t.Fatalf("Failed to list the pods: %v", err)
+	}
+	return pods.Items
+}
+
+// expectEvent fails the test if no event with the given reason was recorded
+func expectEvent(t *testing.T, recorder record.EventRecorder, reason string) {
+	events := recorder.(*record.FakeRecorder).Events
+	for {
+		select {
+		case event := <-events:
+			if strings.Contains(event, " "+reason+" ") {
+				return
+			}
+		default:
+			t.Fatalf("Expected a %s event", reason)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRayClusterNetworkPolicy(t *testing.T) {
+	r := newFakeRayClusterReconciler(newSampleCluster())
+	key := types.NamespacedName{Namespace: "default", Name: utils.GenerateNetworkPolicyName(sampleRequest.Name)}
+	peers := []rayiov1alpha1.NetworkPolicyPeer{
+		{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+	}
+
+	// the steps run in order against the same cluster
+	steps := []struct {
+		name          string
+		networkPolicy *rayiov1alpha1.NetworkPolicySpec
+		ingressRules  int
+		event         string
+	}{
+		{name: "only allows the pods of the cluster and the operator", networkPolicy: &rayiov1alpha1.NetworkPolicySpec{}, ingressRules: 2, event: "Created"},
+		{name: "opens the head ports to the peers", networkPolicy: &rayiov1alpha1.NetworkPolicySpec{From: peers}, ingressRules: 3, event: "Updated"},
+		{name: "deleted once the field is removed", networkPolicy: nil, event: "Deleted"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			updateSampleCluster(t, r, func(cluster *rayiov1alpha1.RayCluster) {
+				cluster.Spec.NetworkPolicy = step.networkPolicy
+			})
+			reconcileSampleCluster(t, r)
+			expectEvent(t, r.Recorder, step.event)
+
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			err := r.Get(context.Background(), key, networkPolicy)
+			if step.networkPolicy == nil {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("Expected the NetworkPolicy to be deleted but got `%v`", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to get the NetworkPolicy: %v", err)
+			}
+			if owners := networkPolicy.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != sampleRequest.Name {
+				t.Fatalf("Expected the NetworkPolicy to be owned by the RayCluster but got `%v`", owners)
+			}
+			if len(networkPolicy.Spec.Ingress) != step.ingressRules {
+				t.Fatalf("Expected `%v` ingress rules but got `%v`", step.ingressRules, networkPolicy.Spec.Ingress)
+			}
+		})
+	}
+}
+
+func TestRayClusterNetworkPolicyConflict(t *testing.T) {
+	cluster := newSampleCluster()
+	cluster.Spec.NetworkPolicy = &rayiov1alpha1.NetworkPolicySpec{}
+	key := types.NamespacedName{Namespace: "default", Name: utils.GenerateNetworkPolicyName(sampleRequest.Name)}
+	other := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	r := newFakeRayClusterReconciler(cluster, other)
+
+	// the NetworkPolicy of someone else is reported and left alone, the pods are still reconciled
+	reconcileSampleCluster(t, r)
+	expectEvent(t, r.Recorder, "NetworkPolicyConflict")
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	if err := r.Get(context.Background(), key, networkPolicy); err != nil {
+		t.Fatalf("Failed to get the NetworkPolicy: %v", err)
+	}
+	if len(networkPolicy.OwnerReferences) != 0 || len(networkPolicy.Spec.Ingress) != 0 {
+		t.Fatalf("Expected the NetworkPolicy to be left alone but got `%v`", networkPolicy)
+	}
+	if pods := listSamplePods(t, r); len(pods) != 1 {
+		t.Fatalf("Expected the head pod to be created but got `%v`", pods)
+	}
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestRayClusterPodDisruptionBudgets(t *testing.T) {
+	r := newFakeRayClusterReconciler(newSampleCluster("small-group"))
+	one, two := intstr.FromInt(1), intstr.FromInt(2)
+
+	// the steps run in order against the same cluster
+	steps := []struct {
+		name   string
+		head   *rayiov1alpha1.PodDisruptionBudgetSpec
+		worker *rayiov1alpha1.PodDisruptionBudgetSpec
+	}{
+		{
+			name:   "created for the head and the workers",
+			head:   &rayiov1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &one},
+			worker: &rayiov1alpha1.PodDisruptionBudgetSpec{MaxUnavailable: &one},
+		},
+		{
+			name:   "updated with the group and deleted once the group doesn't set one",
+			worker: &rayiov1alpha1.PodDisruptionBudgetSpec{MinAvailable: &two},
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			updateSampleCluster(t, r, func(cluster *rayiov1alpha1.RayCluster) {
+				cluster.Spec.HeadGroupSpec.PodDisruptionBudget = step.head
+				cluster.Spec.WorkerGroupSpecs[0].PodDisruptionBudget = step.worker
+			})
+			reconcileSampleCluster(t, r)
+
+			for group, expected := range map[string]*rayiov1alpha1.PodDisruptionBudgetSpec{common.RayHeadGroupName: step.head, "small-group": step.worker} {
+				key := types.NamespacedName{Namespace: "default", Name: utils.GeneratePodDisruptionBudgetName(sampleRequest.Name, group)}
+				budget := &policyv1beta1.PodDisruptionBudget{}
+				err := r.Get(context.Background(), key, budget)
+				if expected == nil {
+					if !errors.IsNotFound(err) {
+						t.Fatalf("Expected the PodDisruptionBudget of %s to be deleted but got `%v`", group, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Failed to get the PodDisruptionBudget of %s: %v", group, err)
+				}
+				if owners := budget.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != sampleRequest.Name {
+					t.Fatalf("Expected the PodDisruptionBudget to be owned by the RayCluster but got `%v`", owners)
+				}
+				if !reflect.DeepEqual(budget.Spec.MaxUnavailable, expected.MaxUnavailable) || !reflect.DeepEqual(budget.Spec.MinAvailable, expected.MinAvailable) {
+					t.Fatalf("Expected `%v` but got `%v`", expected, budget.Spec)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/batchscheduler"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+)
+
+func TestRayClusterPodGroup(t *testing.T) {
+	cluster := newSampleCluster("small-group")
+	cluster.Spec.BatchScheduler = &rayiov1alpha1.BatchSchedulerSpec{Name: batchscheduler.VolcanoName}
+	r := newFakeRayClusterReconciler(cluster)
+
+	// the steps run in order against the same cluster, the PodGroup gathers the head and the minimum workers
+	steps := []struct {
+		name        string
+		minReplicas int32
+		minMember   int64
+	}{
+		{name: "created with the minimum workers", minReplicas: 2, minMember: 3},
+		{name: "follows the minimum workers", minReplicas: 3, minMember: 4},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			updateSampleCluster(t, r, func(cluster *rayiov1alpha1.RayCluster) {
+				cluster.Spec.WorkerGroupSpecs[0].MinReplicas = pointer.Int32Ptr(step.minReplicas)
+			})
+			reconcileSampleCluster(t, r)
+
+			podGroup := &unstructured.Unstructured{}
+			podGroup.SetGroupVersionKind((&batchscheduler.VolcanoScheduler{}).PodGroupGVK())
+			if err := r.Get(context.Background(), sampleRequest.NamespacedName, podGroup); err != nil {
+				t.Fatalf("Failed to get the PodGroup: %v", err)
+			}
+			if minMember, _, _ := unstructured.NestedInt64(podGroup.Object, "spec", "minMember"); minMember != step.minMember {
+				t.Fatalf("Expected `%v` but got `%v`", step.minMember, minMember)
+			}
+			if owners := podGroup.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != sampleRequest.Name {
+				t.Fatalf("Expected the PodGroup to be owned by the RayCluster but got `%v`", owners)
+			}
+			// the pods are scheduled by volcano in the PodGroup
+			pods := listSamplePods(t, r)
+			if len(pods) == 0 {
+				t.Fatalf("Expected the head pod to be created")
+			}
+			for _, pod := range pods {
+				if pod.Spec.SchedulerName != batchscheduler.VolcanoName || pod.Annotations[batchscheduler.VolcanoPodGroupAnnotationKey] != sampleRequest.Name {
+					t.Fatalf("Expected pod %s to be in the PodGroup but got `%v`, `%v`", pod.Name, pod.Spec.SchedulerName, pod.Annotations)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestRayClusterPortsFromRayStartParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		rayStartParams map[string]string
+		dashboardPort  int32
+		mismatch       bool
+	}{
+		{name: "default ports", rayStartParams: map[string]string{}, dashboardPort: common.DefaultDashboardPort},
+		{name: "port of the params", rayStartParams: map[string]string{"dashboard-port": "8266"}, dashboardPort: 8266, mismatch: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := newSampleCluster()
+			cluster.Spec.HeadGroupSpec.RayStartParams = tc.rayStartParams
+			cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+				{Name: common.DefaultDashboardName, ContainerPort: common.DefaultDashboardPort},
+			}
+			r := newFakeRayClusterReconciler(cluster)
+			reconcileSampleCluster(t, r)
+
+			// the container port disagreeing with the params is reported
+			if tc.mismatch {
+				expectEvent(t, r.Recorder, "PortMismatch")
+			}
+
+			// the service and the head container use the port of the params
+			svc := &corev1.Service{}
+			if err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: utils.GenerateServiceName(sampleRequest.Name)}, svc); err != nil {
+				t.Fatalf("Failed to get the head service: %v", err)
+			}
+			servicePorts := map[string]int32{}
+			for _, port := range svc.Spec.Ports {
+				servicePorts[port.Name] = port.Port
+			}
+			if servicePorts[common.DefaultDashboardName] != tc.dashboardPort || servicePorts[common.DefaultRedisPortName] != common.DefaultRedisPort {
+				t.Fatalf("Expected the dashboard port `%v` but got `%v`", tc.dashboardPort, servicePorts)
+			}
+			pods := listSamplePods(t, r)
+			if len(pods) != 1 {
+				t.Fatalf("Expected the head pod to be created but got `%v`", pods)
+			}
+			containerPorts := map[string]int32{}
+			for _, port := range pods[0].Spec.Containers[0].Ports {
+				containerPorts[port.Name] = port.ContainerPort
+			}
+			for name, port := range servicePorts {
+				if containerPorts[name] != port {
+					t.Fatalf("Expected container port %s to be `%v` but got `%v`", name, port, containerPorts[name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+func TestRayClusterSuspend(t *testing.T) {
+	r := newFakeRayClusterReconciler(
+		newSampleCluster(),
+		newSamplePod("raycluster-sample-head", rayiov1alpha1.HeadNode, common.RayHeadGroupName),
+		newSamplePod("raycluster-sample-worker", rayiov1alpha1.WorkerNode, "small-group"),
+	)
+
+	// the steps run in order against the same cluster
+	steps := []struct {
+		name      string
+		suspend   bool
+		headPods  int
+		suspended bool
+	}{
+		{name: "suspending deletes the pods", suspend: true, headPods: 0, suspended: true},
+		{name: "resuming creates the head pod again", suspend: false, headPods: 1, suspended: false},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			// the cache observed the previous deletions
+			r.Expectations.DeleteClusterExpectations(sampleRequest.Namespace, sampleRequest.Name)
+			updateSampleCluster(t, r, func(cluster *rayiov1alpha1.RayCluster) {
+				cluster.Spec.Suspend = pointer.BoolPtr(step.suspend)
+			})
+
+			_, current := reconcileSampleCluster(t, r)
+			pods := listSamplePods(t, r)
+			if len(pods) != step.headPods || (step.headPods == 1 && pods[0].Labels[common.RayNodeTypeLabelKey] != string(rayiov1alpha1.HeadNode)) {
+				t.Fatalf("Expected `%v` head pods but got `%v`", step.headPods, pods)
+			}
+			if suspended := current.Status.State == rayiov1alpha1.Suspended; suspended != step.suspended {
+				t.Fatalf("Expected suspended `%v` but got `%v`", step.suspended, current.Status.State)
+			}
+			// the head service is kept
+			service := &corev1.Service{}
+			if err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "raycluster-sample-head-svc"}, service); err != nil {
+				t.Fatalf("Expected the head service to be kept but got `%v`", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	jobLog = logf.Log.WithName("rayjob-controller")
+	// RayJobStatusPollInterval is the interval at which the status of a running job is read from the job server
+	RayJobStatusPollInterval = 3 * time.Second
+)
+
+// NewRayJobReconciler returns a new reconcile.Reconciler
+func NewRayJobReconciler(mgr manager.Manager) *RayJobReconciler {
+	return &RayJobReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Recorder: mgr.GetEventRecorderFor("rayjob-controller"),
+
+		DashboardClient: dashboard.NewClient,
+	}
+}
+
+var _ reconcile.Reconciler = &RayJobReconciler{}
+
+// RayJobReconciler reconciles a RayJob object
+type RayJobReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// DashboardClient returns the client of the job server of a RayCluster
+	DashboardClient dashboard.ClientFactory
+}
+
+// Reconcile creates the RayCluster of a RayJob, submits the job once the head is ready,
+// tracks its status and deletes the RayCluster when the job finished and its TTL expired
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ray.io,resources=rayjobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("rayjob", request.NamespacedName)
+	jobLog.Info("reconciling RayJob", "job name", request.Name)
+
+	rayJob := &rayiov1alpha1.RayJob{}
+	if err := r.Get(ctx, request.NamespacedName, rayJob); err != nil {
+		// the RayCluster is garbage collected through its owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if rayJob.DeletionTimestamp != nil && !rayJob.DeletionTimestamp.IsZero() {
+		jobLog.Info("RayJob is being deleted, just ignore", "job name", request.Name)
+		return ctrl.Result{}, nil
+	}
+
+	originalStatus := rayJob.Status.DeepCopy()
+	result, reconcileErr := r.reconcileJob(ctx, rayJob)
+	if !reflect.DeepEqual(*originalStatus, rayJob.Status) {
+		if err := r.Status().Update(ctx, rayJob); err != nil {
+			jobLog.Error(err, "Update status error", "job name", request.Name)
+			if reconcileErr == nil {
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
+		}
+	}
+	return result, reconcileErr
+}
+
+// reconcileJob moves the job through its deployment steps and records the progress in its status
+func (r *RayJobReconciler) reconcileJob(ctx context.Context, rayJob *rayiov1alpha1.RayJob) (ctrl.Result, error) {
+	if rayJob.Status.JobDeploymentStatus == rayiov1alpha1.JobDeploymentStatusComplete {
+		return r.reconcileFinishedJob(ctx, rayJob)
+	}
+
+	// the job id is stored before the submission, so the same job is looked up after a restart of the operator
+	if rayJob.Status.JobID == "" || rayJob.Status.RayClusterName == "" {
+		rayJob.Status.JobID = rayJob.Spec.JobID
+		if rayJob.Status.JobID == "" {
+			rayJob.Status.JobID = fmt.Sprintf("%s-%s", rayJob.Name, utilrand.String(5))
+		}
+		rayJob.Status.RayClusterName = utils.CheckName(rayJob.Name + "-raycluster")
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusInitializing
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	cluster, err := r.getOrCreateRayCluster(ctx, rayJob)
+	if err != nil {
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusFailedToGetOrCreateRayCluster
+		rayJob.Status.Message = err.Error()
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	// the RayCluster is watched, its status changes trigger a new reconciliation
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(rayiov1alpha1.HeadPodReady)) {
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusWaitForCluster
+		rayJob.Status.Message = fmt.Sprintf("waiting for the head pod of RayCluster %s", cluster.Name)
+		return ctrl.Result{}, nil
+	}
+
+	rayJob.Status.DashboardURL = common.GetDashboardURL(*cluster)
+	dashboardClient := r.DashboardClient(rayJob.Status.DashboardURL)
+	jobInfo, err := dashboardClient.GetJobInfo(ctx, rayJob.Status.JobID)
+	if err == dashboard.ErrNotFound {
+		if rayJob.Status.JobDeploymentStatus != rayiov1alpha1.JobDeploymentStatusRunning {
+			return r.submitJob(ctx, rayJob, dashboardClient)
+		}
+		// the job was submitted, it was lost with the job server, e.g. when the head restarted, and isn't run twice
+		r.failLostJob(rayJob)
+		return r.reconcileFinishedJob(ctx, rayJob)
+	}
+	if err != nil {
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusFailedToGetJobStatus
+		rayJob.Status.Message = err.Error()
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	r.updateJobStatus(rayJob, jobInfo)
+	if rayJob.Status.JobDeploymentStatus == rayiov1alpha1.JobDeploymentStatusComplete {
+		return r.reconcileFinishedJob(ctx, rayJob)
+	}
+	// the job server can't be watched, the status is polled
+	return ctrl.Result{RequeueAfter: RayJobStatusPollInterval}, nil
+}
+
+// getOrCreateRayCluster returns the RayCluster of the job, it is created from the spec of the job the first time
+func (r *RayJobReconciler) getOrCreateRayCluster(ctx context.Context, rayJob *rayiov1alpha1.RayJob) (*rayiov1alpha1.RayCluster, error) {
+	cluster := &rayiov1alpha1.RayCluster{}
+	key := types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Status.RayClusterName}
+	err := r.Get(ctx, key, cluster)
+	if err == nil {
+		if !metav1.IsControlledBy(cluster, rayJob) {
+			return nil, fmt.Errorf("RayCluster %s already exists and is not owned by RayJob %s", cluster.Name, rayJob.Name)
+		}
+		return cluster, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cluster = &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rayJob.Status.RayClusterName,
+			Namespace: rayJob.Namespace,
+			Labels:    map[string]string{common.RayJobLabelKey: rayJob.Name},
+		},
+		Spec: *rayJob.Spec.RayClusterSpec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(rayJob, cluster, r.Scheme); err != nil {
+		return nil, err
+	}
+	jobLog.Info("getOrCreateRayCluster", "creating RayCluster", cluster.Name, "job name", rayJob.Name)
+	if err := r.Create(ctx, cluster); err != nil {
+		return nil, err
+	}
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, "CreatedRayCluster", "Created RayCluster %s", cluster.Name)
+	return cluster, nil
+}
+
+// submitJob submits the job to the job server of the RayCluster
+func (r *RayJobReconciler) submitJob(ctx context.Context, rayJob *rayiov1alpha1.RayJob, dashboardClient dashboard.Client) (ctrl.Result, error) {
+	request := &dashboard.JobSubmitRequest{
+		Entrypoint: rayJob.Spec.Entrypoint,
+		JobID:      rayJob.Status.JobID,
+		Metadata:   rayJob.Spec.Metadata,
+	}
+	if rayJob.Spec.RuntimeEnv != "" {
+		if err := json.Unmarshal([]byte(rayJob.Spec.RuntimeEnv), &request.RuntimeEnv); err != nil {
+			// retrying won't help, the next update of the spec triggers a new reconciliation
+			rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusFailedJobDeploy
+			rayJob.Status.Message = fmt.Sprintf("invalid runtimeEnv: %v", err)
+			r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, "InvalidRuntimeEnv", "Invalid runtimeEnv: %v", err)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	jobLog.Info("submitJob", "job id", rayJob.Status.JobID, "dashboard", rayJob.Status.DashboardURL)
+	if _, err := dashboardClient.SubmitJob(ctx, request); err != nil {
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusFailedJobDeploy
+		rayJob.Status.Message = err.Error()
+		r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, "FailedJobDeploy", "Failed to submit job %s: %v", rayJob.Status.JobID, err)
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, "SubmittedJob", "Submitted job %s to RayCluster %s", rayJob.Status.JobID, rayJob.Status.RayClusterName)
+	rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusRunning
+	rayJob.Status.JobStatus = rayiov1alpha1.JobStatusPending
+	rayJob.Status.Message = ""
+	return ctrl.Result{RequeueAfter: RayJobStatusPollInterval}, nil
+}
+
+// updateJobStatus copies the status reported by the job server into the status of the RayJob
+func (r *RayJobReconciler) updateJobStatus(rayJob *rayiov1alpha1.RayJob, jobInfo *dashboard.JobInfo) {
+	jobStatus := rayiov1alpha1.JobStatus(jobInfo.Status)
+	if rayJob.Status.JobStatus != jobStatus {
+		jobLog.Info("updateJobStatus", "job name", rayJob.Name, "old status", rayJob.Status.JobStatus, "new status", jobStatus)
+		r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, "JobStatusChanged", "Job %s changed from %q to %q", rayJob.Status.JobID, rayJob.Status.JobStatus, jobStatus)
+	}
+	rayJob.Status.JobStatus = jobStatus
+	rayJob.Status.Message = jobInfo.Message
+	if jobInfo.StartTime > 0 {
+		startTime := metav1.NewTime(time.Unix(0, jobInfo.StartTime*int64(time.Millisecond)))
+		rayJob.Status.StartTime = &startTime
+	}
+	if jobInfo.EndTime > 0 {
+		endTime := metav1.NewTime(time.Unix(0, jobInfo.EndTime*int64(time.Millisecond)))
+		rayJob.Status.EndTime = &endTime
+	}
+
+	if !rayiov1alpha1.IsJobTerminal(jobStatus) {
+		rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusRunning
+		return
+	}
+	rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusComplete
+	if rayJob.Status.EndTime == nil {
+		now := metav1.Now()
+		rayJob.Status.EndTime = &now
+	}
+	eventType := corev1.EventTypeNormal
+	if jobStatus == rayiov1alpha1.JobStatusFailed {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(rayJob, eventType, "JobFinished", "Job %s finished with status %q", rayJob.Status.JobID, jobStatus)
+}
+
+// failLostJob marks the job as failed when the job server doesn't know it anymore
+func (r *RayJobReconciler) failLostJob(rayJob *rayiov1alpha1.RayJob) {
+	jobLog.Info("failLostJob", "job name", rayJob.Name, "job id", rayJob.Status.JobID)
+	rayJob.Status.JobStatus = rayiov1alpha1.JobStatusFailed
+	rayJob.Status.JobDeploymentStatus = rayiov1alpha1.JobDeploymentStatusComplete
+	rayJob.Status.Message = fmt.Sprintf("job %s is not found in the job server of RayCluster %s", rayJob.Status.JobID, rayJob.Status.RayClusterName)
+	now := metav1.Now()
+	rayJob.Status.EndTime = &now
+	r.Recorder.Eventf(rayJob, corev1.EventTypeWarning, "JobLost", "Job %s is not found in the job server of RayCluster %s",
+		rayJob.Status.JobID, rayJob.Status.RayClusterName)
+}
+
+// reconcileFinishedJob deletes the RayCluster of a finished job once TTLSecondsAfterFinished expired
+func (r *RayJobReconciler) reconcileFinishedJob(ctx context.Context, rayJob *rayiov1alpha1.RayJob) (ctrl.Result, error) {
+	if rayJob.Spec.TTLSecondsAfterFinished == nil || rayJob.Status.EndTime == nil {
+		return ctrl.Result{}, nil
+	}
+	ttl := time.Duration(*rayJob.Spec.TTLSecondsAfterFinished) * time.Second
+	if remaining := time.Until(rayJob.Status.EndTime.Add(ttl)); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	cluster := &rayiov1alpha1.RayCluster{}
+	key := types.NamespacedName{Namespace: rayJob.Namespace, Name: rayJob.Status.RayClusterName}
+	if err := r.Get(ctx, key, cluster); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(cluster, rayJob) || cluster.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	jobLog.Info("reconcileFinishedJob", "deleting RayCluster", cluster.Name, "job name", rayJob.Name)
+	if err := r.Delete(ctx, cluster, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, client.IgnoreNotFound(err)
+	}
+	r.Recorder.Eventf(rayJob, corev1.EventTypeNormal, "DeletedRayCluster", "Deleted RayCluster %s after the job finished", cluster.Name)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers the RayJob controller, it watches the RayClusters created for the jobs
+func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rayiov1alpha1.RayJob{}).Named("rayjob-controller").
+		Owns(&rayiov1alpha1.RayCluster{}).
+		Complete(r)
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// fakeDashboardClient keeps the submitted jobs and the deployed Serve config in memory
+type fakeDashboardClient struct {
+	jobs        map[string]*dashboard.JobInfo
+	serveConfig *dashboard.ServeConfig
+	serveStatus dashboard.ServeStatus
+	// serveErr is returned by GetServeStatus when the dashboard is unreachable
+	serveErr error
+}
+
+func (c *fakeDashboardClient) SubmitJob(_ context.Context, request *dashboard.JobSubmitRequest) (string, error) {
+	c.jobs[request.JobID] = &dashboard.JobInfo{Status: string(rayiov1alpha1.JobStatusPending), Entrypoint: request.Entrypoint}
+	return request.JobID, nil
+}
+
+func (c *fakeDashboardClient) GetJobInfo(_ context.Context, jobID string) (*dashboard.JobInfo, error) {
+	info, ok := c.jobs[jobID]
+	if !ok {
+		return nil, dashboard.ErrNotFound
+	}
+	return info, nil
+}
+
+func (c *fakeDashboardClient) ListJobs(_ context.Context) ([]dashboard.JobInfo, error) {
+	jobs := []dashboard.JobInfo{}
+	for _, info := range c.jobs {
+		jobs = append(jobs, *info)
+	}
+	return jobs, nil
+}
+
+func (c *fakeDashboardClient) StopJob(_ context.Context, jobID string) error {
+	c.jobs[jobID].Status = string(rayiov1alpha1.JobStatusStopped)
+	return nil
+}
+
+func (c *fakeDashboardClient) UpdateServeDeployments(_ context.Context, config *dashboard.ServeConfig) error {
+	c.serveConfig = config
+	c.serveStatus = dashboard.ServeStatus{AppStatus: dashboard.ServeAppStatus{Status: "DEPLOYING"}}
+	for _, deployment := range config.Deployments {
+		c.serveStatus.DeploymentStatuses = append(c.serveStatus.DeploymentStatuses, dashboard.ServeDeploymentStatus{
+			Name:   deployment.Name,
+			Status: string(rayiov1alpha1.ServeDeploymentUpdating),
+		})
+	}
+	return nil
+}
+
+func (c *fakeDashboardClient) GetServeStatus(_ context.Context) (*dashboard.ServeStatus, error) {
+	if c.serveErr != nil {
+		return nil, c.serveErr
+	}
+	status := c.serveStatus
+	return &status, nil
+}
+
+// setHealthy marks the deployed Serve application as running and healthy
+func (c *fakeDashboardClient) setHealthy() {
+	c.serveStatus.AppStatus.Status = rayiov1alpha1.ServeAppRunning
+	for index := range c.serveStatus.DeploymentStatuses {
+		c.serveStatus.DeploymentStatuses[index].Status = string(rayiov1alpha1.ServeDeploymentHealthy)
+	}
+}
+
+func TestRayJobReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rayiov1alpha1.AddToScheme(scheme)
+
+	rayJob := &rayiov1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob-sample", Namespace: "default"},
+		Spec: rayiov1alpha1.RayJobSpec{
+			Entrypoint:              "python /home/ray/samples/job.py",
+			JobID:                   "rayjob-sample-1",
+			TTLSecondsAfterFinished: pointer.Int32Ptr(0),
+			RayClusterSpec: rayiov1alpha1.RayClusterSpec{
+				HeadGroupSpec: rayiov1alpha1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:1.9.0"}}},
+					},
+				},
+			},
+		},
+	}
+	dashboardClient := &fakeDashboardClient{jobs: map[string]*dashboard.JobInfo{}}
+	r := &RayJobReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(rayJob).Build(),
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Recorder: record.NewFakeRecorder(100),
+		DashboardClient: func(url string) dashboard.Client {
+			if url != "http://rayjob-sample-raycluster-head-svc.default.svc:8265" {
+				t.Fatalf("Expected the dashboard of the RayCluster but got `%v`", url)
+			}
+			return dashboardClient
+		},
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "rayjob-sample"}}
+	reconcileAndGet := func() *rayiov1alpha1.RayJob {
+		if _, err := r.Reconcile(context.Background(), request); err != nil {
+			t.Fatalf("Failed to reconcile: %v", err)
+		}
+		current := &rayiov1alpha1.RayJob{}
+		if err := r.Get(context.Background(), request.NamespacedName, current); err != nil {
+			t.Fatalf("Failed to get the RayJob: %v", err)
+		}
+		return current
+	}
+
+	// the job id and cluster name are stored first
+	current := reconcileAndGet()
+	if current.Status.JobID != "rayjob-sample-1" || current.Status.RayClusterName != "rayjob-sample-raycluster" {
+		t.Fatalf("Expected the job to be initialized but got `%v`", current.Status)
+	}
+
+	// the RayCluster is created and the job waits for its head
+	current = reconcileAndGet()
+	if current.Status.JobDeploymentStatus != rayiov1alpha1.JobDeploymentStatusWaitForCluster {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.JobDeploymentStatusWaitForCluster, current.Status.JobDeploymentStatus)
+	}
+	cluster := &rayiov1alpha1.RayCluster{}
+	clusterKey := types.NamespacedName{Namespace: "default", Name: "rayjob-sample-raycluster"}
+	if err := r.Get(context.Background(), clusterKey, cluster); err != nil {
+		t.Fatalf("Failed to get the RayCluster: %v", err)
+	}
+	if !metav1.IsControlledBy(cluster, current) {
+		t.Fatalf("Expected the RayCluster to be owned by the RayJob")
+	}
+
+	// the job is submitted once the head is ready
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   string(rayiov1alpha1.HeadPodReady),
+		Status: metav1.ConditionTrue,
+		Reason: "HeadPodRunning",
+	})
+	if err := r.Status().Update(context.Background(), cluster); err != nil {
+		t.Fatalf("Failed to update the RayCluster: %v", err)
+	}
+	current = reconcileAndGet()
+	if _, ok := dashboardClient.jobs["rayjob-sample-1"]; !ok {
+		t.Fatalf("Expected the job to be submitted")
+	}
+	if current.Status.JobDeploymentStatus != rayiov1alpha1.JobDeploymentStatusRunning || current.Status.JobStatus != rayiov1alpha1.JobStatusPending {
+		t.Fatalf("Expected a pending job but got `%v`", current.Status)
+	}
+
+	// the job is not submitted twice
+	dashboardClient.jobs["rayjob-sample-1"].Status = string(rayiov1alpha1.JobStatusRunning)
+	current = reconcileAndGet()
+	if current.Status.JobStatus != rayiov1alpha1.JobStatusRunning {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.JobStatusRunning, current.Status.JobStatus)
+	}
+
+	// the RayCluster is deleted when the job finished and the TTL expired
+	dashboardClient.jobs["rayjob-sample-1"].Status = string(rayiov1alpha1.JobStatusSucceeded)
+	dashboardClient.jobs["rayjob-sample-1"].EndTime = time.Now().Add(-time.Second).UnixNano() / int64(time.Millisecond)
+	current = reconcileAndGet()
+	if current.Status.JobDeploymentStatus != rayiov1alpha1.JobDeploymentStatusComplete || current.Status.EndTime == nil {
+		t.Fatalf("Expected a complete job but got `%v`", current.Status)
+	}
+	if err := r.Get(context.Background(), clusterKey, cluster); !errors.IsNotFound(err) {
+		t.Fatalf("Expected the RayCluster to be deleted but got `%v`", err)
+	}
+}
+
+func TestRayJobLost(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rayiov1alpha1.AddToScheme(scheme)
+
+	// the job was submitted and the head of its RayCluster restarted since
+	rayJob := &rayiov1alpha1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob-sample", Namespace: "default"},
+		Spec:       rayiov1alpha1.RayJobSpec{Entrypoint: "python /home/ray/samples/job.py"},
+		Status: rayiov1alpha1.RayJobStatus{
+			JobID:               "rayjob-sample-1",
+			RayClusterName:      "rayjob-sample-raycluster",
+			JobStatus:           rayiov1alpha1.JobStatusRunning,
+			JobDeploymentStatus: rayiov1alpha1.JobDeploymentStatusRunning,
+		},
+	}
+	cluster := &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjob-sample-raycluster", Namespace: "default"},
+		Status: rayiov1alpha1.RayClusterStatus{
+			Conditions: []metav1.Condition{{Type: string(rayiov1alpha1.HeadPodReady), Status: metav1.ConditionTrue, Reason: "HeadPodRunning"}},
+		},
+	}
+	if err := controllerutil.SetControllerReference(rayJob, cluster, scheme); err != nil {
+		t.Fatalf("Failed to set the owner of the RayCluster: %v", err)
+	}
+	dashboardClient := &fakeDashboardClient{jobs: map[string]*dashboard.JobInfo{}}
+	r := &RayJobReconciler{
+		Client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(rayJob, cluster).Build(),
+		Scheme:          scheme,
+		Log:             ctrl.Log.WithName("controllers").WithName("RayJob"),
+		Recorder:        record.NewFakeRecorder(100),
+		DashboardClient: func(string) dashboard.Client { return dashboardClient },
+	}
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "rayjob-sample"}}
+
+	if _, err := r.Reconcile(context.Background(), request); err != nil {
+		t.Fatalf("Failed to reconcile: %v", err)
+	}
+	current := &rayiov1alpha1.RayJob{}
+	if err := r.Get(context.Background(), request.NamespacedName, current); err != nil {
+		t.Fatalf("Failed to get the RayJob: %v", err)
+	}
+	if len(dashboardClient.jobs) != 0 {
+		t.Fatalf("Expected the job not to be submitted again but got `%v`", dashboardClient.jobs)
+	}
+	if current.Status.JobStatus != rayiov1alpha1.JobStatusFailed || current.Status.JobDeploymentStatus != rayiov1alpha1.JobDeploymentStatusComplete ||
+		current.Status.Message == "" || current.Status.EndTime == nil {
+		t.Fatalf("Expected a failed job but got `%v`", current.Status)
+	}
+	expectEvent(t, r.Recorder, "JobLost")
+	// the RayCluster is kept without TTL
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: cluster.Name}, cluster); err != nil {
+		t.Fatalf("Expected the RayCluster to be kept but got `%v`", err)
+	}
+}
@@ -0,0 +1,437 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	serviceLog = logf.Log.WithName("rayservice-controller")
+	// RayServiceStatusPollInterval is the interval at which the health of the Serve deployments is read from the dashboard
+	RayServiceStatusPollInterval = 5 * time.Second
+)
+
+// NewRayServiceReconciler returns a new reconcile.Reconciler
+func NewRayServiceReconciler(mgr manager.Manager) *RayServiceReconciler {
+	return &RayServiceReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Log:      ctrl.Log.WithName("controllers").WithName("RayService"),
+		Recorder: mgr.GetEventRecorderFor("rayservice-controller"),
+
+		DashboardClient: dashboard.NewClient,
+	}
+}
+
+var _ reconcile.Reconciler = &RayServiceReconciler{}
+
+// RayServiceReconciler reconciles a RayService object
+type RayServiceReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// DashboardClient returns the client of the dashboard of a RayCluster
+	DashboardClient dashboard.ClientFactory
+}
+
+// Reconcile deploys the Serve config of a RayService to its RayCluster and tracks the health of the deployments.
+// A change of the RayCluster spec brings up a new RayCluster, the Serve service is switched to it once healthy.
+// +kubebuilder:rbac:groups=ray.io,resources=rayservices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ray.io,resources=rayservices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ray.io,resources=rayservices/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	_ = r.Log.WithValues("rayservice", request.NamespacedName)
+	serviceLog.Info("reconciling RayService", "service name", request.Name)
+
+	rayService := &rayiov1alpha1.RayService{}
+	if err := r.Get(ctx, request.NamespacedName, rayService); err != nil {
+		// the RayClusters and the Serve service are garbage collected through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if rayService.DeletionTimestamp != nil && !rayService.DeletionTimestamp.IsZero() {
+		serviceLog.Info("RayService is being deleted, just ignore", "service name", request.Name)
+		return ctrl.Result{}, nil
+	}
+
+	originalStatus := rayService.Status.DeepCopy()
+	result, reconcileErr := r.reconcileService(ctx, rayService)
+	if !reflect.DeepEqual(*originalStatus, rayService.Status) {
+		if err := r.Status().Update(ctx, rayService); err != nil {
+			serviceLog.Error(err, "Update status error", "service name", request.Name)
+			if reconcileErr == nil {
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
+		}
+	}
+	return result, reconcileErr
+}
+
+// reconcileService brings up the RayCluster of the current spec, deploys the Serve config and switches the Serve
+// service to the new RayCluster once its deployments are healthy
+func (r *RayServiceReconciler) reconcileService(ctx context.Context, rayService *rayiov1alpha1.RayService) (ctrl.Result, error) {
+	clusterHash, err := common.GenerateRayClusterSpecHash(rayService.Spec.RayClusterSpec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	activeCluster, err := r.getRayCluster(ctx, rayService, rayService.Status.ActiveServiceStatus.RayClusterName)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if activeCluster == nil {
+		rayService.Status.ActiveServiceStatus = rayiov1alpha1.RayClusterServeStatus{}
+	}
+
+	// the RayCluster of the current spec is named after the hash of the spec, so it is only created once
+	var pendingCluster *rayiov1alpha1.RayCluster
+	if activeCluster == nil || activeCluster.Annotations[common.RayClusterHashKey] != clusterHash {
+		pendingCluster, err = r.getOrCreatePendingCluster(ctx, rayService, clusterHash)
+		if err != nil {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceFailedToGetOrCreateRayCluster
+			rayService.Status.PendingServiceStatus.Message = err.Error()
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if rayService.Status.PendingServiceStatus.RayClusterName != pendingCluster.Name {
+			rayService.Status.PendingServiceStatus = rayiov1alpha1.RayClusterServeStatus{RayClusterName: pendingCluster.Name}
+		}
+	} else {
+		rayService.Status.PendingServiceStatus = rayiov1alpha1.RayClusterServeStatus{}
+	}
+
+	// the RayClusters of outdated specs are deleted, e.g. a pending one replaced before it became healthy
+	if err := r.cleanUpRayClusters(ctx, rayService); err != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
+	// the error of the active RayCluster is recorded in its status, it must not block the promotion of the pending
+	// one which is often the way out of a broken active RayCluster
+	var activeErr error
+	if activeCluster != nil {
+		// the Serve config is deployed in place only if the RayCluster is not replaced
+		healthy, err := r.reconcileServe(ctx, rayService, activeCluster, &rayService.Status.ActiveServiceStatus, pendingCluster == nil)
+		if err := r.reconcileServeService(ctx, rayService, activeCluster); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if err != nil {
+			activeErr = err
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceFailedServeDeploy
+		} else if healthy {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceRunning
+		} else {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceWaitForServeDeploymentReady
+		}
+	}
+
+	if pendingCluster != nil {
+		healthy, err := r.reconcileServe(ctx, rayService, pendingCluster, &rayService.Status.PendingServiceStatus, true)
+		if err != nil {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceFailedServeDeploy
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		if healthy {
+			if err := r.promotePendingCluster(ctx, rayService, activeCluster, pendingCluster); err != nil {
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
+			// the failed RayCluster was replaced
+			activeErr = nil
+		} else if activeCluster == nil {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceWaitForServeDeploymentReady
+		} else if rayService.Status.ServiceStatus == rayiov1alpha1.RayServiceRunning {
+			rayService.Status.ServiceStatus = rayiov1alpha1.RayServicePreparingNewCluster
+		}
+	}
+	if activeErr != nil {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, activeErr
+	}
+
+	// the dashboard can't be watched, the health of the deployments is polled
+	return ctrl.Result{RequeueAfter: RayServiceStatusPollInterval}, nil
+}
+
+// getRayCluster returns the RayCluster of the service with the given name, or nil if it doesn't exist
+func (r *RayServiceReconciler) getRayCluster(ctx context.Context, rayService *rayiov1alpha1.RayService, name string) (*rayiov1alpha1.RayCluster, error) {
+	if name == "" {
+		return nil, nil
+	}
+	cluster := &rayiov1alpha1.RayCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: rayService.Namespace, Name: name}, cluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(cluster, rayService) || cluster.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return cluster, nil
+}
+
+// getOrCreatePendingCluster returns the RayCluster of the current spec, it is created the first time
+func (r *RayServiceReconciler) getOrCreatePendingCluster(ctx context.Context, rayService *rayiov1alpha1.RayService, clusterHash string) (*rayiov1alpha1.RayCluster, error) {
+	name := utils.CheckName(fmt.Sprintf("%s-raycluster-%s", rayService.Name, clusterHash))
+	cluster := &rayiov1alpha1.RayCluster{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: rayService.Namespace, Name: name}, cluster)
+	if err == nil {
+		if !metav1.IsControlledBy(cluster, rayService) {
+			return nil, fmt.Errorf("RayCluster %s already exists and is not owned by RayService %s", name, rayService.Name)
+		}
+		return cluster, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	cluster = &rayiov1alpha1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   rayService.Namespace,
+			Labels:      map[string]string{common.RayServiceLabelKey: rayService.Name},
+			Annotations: map[string]string{common.RayClusterHashKey: clusterHash},
+		},
+		Spec: *rayService.Spec.RayClusterSpec.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(rayService, cluster, r.Scheme); err != nil {
+		return nil, err
+	}
+	serviceLog.Info("getOrCreatePendingCluster", "creating RayCluster", name, "service name", rayService.Name)
+	if err := r.Create(ctx, cluster); err != nil {
+		return nil, err
+	}
+	r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "CreatedRayCluster", "Created RayCluster %s", name)
+	return cluster, nil
+}
+
+// cleanUpRayClusters deletes the RayClusters of the service that are neither active nor pending
+func (r *RayServiceReconciler) cleanUpRayClusters(ctx context.Context, rayService *rayiov1alpha1.RayService) error {
+	clusters := rayiov1alpha1.RayClusterList{}
+	filterLabels := client.MatchingLabels{common.RayServiceLabelKey: rayService.Name}
+	if err := r.List(ctx, &clusters, client.InNamespace(rayService.Namespace), filterLabels); err != nil {
+		return err
+	}
+	for index := range clusters.Items {
+		cluster := &clusters.Items[index]
+		if cluster.Name == rayService.Status.ActiveServiceStatus.RayClusterName || cluster.Name == rayService.Status.PendingServiceStatus.RayClusterName {
+			continue
+		}
+		if !metav1.IsControlledBy(cluster, rayService) || cluster.DeletionTimestamp != nil {
+			continue
+		}
+		serviceLog.Info("cleanUpRayClusters", "deleting RayCluster", cluster.Name, "service name", rayService.Name)
+		if err := r.Delete(ctx, cluster, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "DeletedRayCluster", "Deleted outdated RayCluster %s", cluster.Name)
+	}
+	return nil
+}
+
+// reconcileServe deploys the Serve config to the RayCluster if deploy is true and it changed, then records the
+// health of the deployments. It returns true if all the deployments are healthy.
+func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayService *rayiov1alpha1.RayService, cluster *rayiov1alpha1.RayCluster, clusterStatus *rayiov1alpha1.RayClusterServeStatus, deploy bool) (bool, error) {
+	if !meta.IsStatusConditionTrue(cluster.Status.Conditions, string(rayiov1alpha1.HeadPodReady)) {
+		clusterStatus.Message = fmt.Sprintf("waiting for the head pod of RayCluster %s", cluster.Name)
+		return false, nil
+	}
+
+	dashboardClient := r.DashboardClient(common.GetDashboardURL(*cluster))
+	serveStatus, err := dashboardClient.GetServeStatus(ctx)
+	if err != nil {
+		clusterStatus.Message = err.Error()
+		return false, err
+	}
+
+	configHash, err := common.GenerateServeConfigHash(rayService.Spec.ServeConfig)
+	if err != nil {
+		return false, err
+	}
+	// the Serve config is lost when the head pod is recreated, it is deployed again when no deployment is reported
+	if deploy && (clusterStatus.ServeConfigHash != configHash || len(serveStatus.DeploymentStatuses) == 0) {
+		config, err := buildServeConfig(rayService.Spec.ServeConfig)
+		if err != nil {
+			// retrying won't help, the next update of the spec triggers a new reconciliation
+			clusterStatus.Message = fmt.Sprintf("invalid serveConfig: %v", err)
+			r.Recorder.Eventf(rayService, corev1.EventTypeWarning, "InvalidServeConfig", "Invalid serveConfig: %v", err)
+			return false, nil
+		}
+		serviceLog.Info("reconcileServe", "deploying Serve config to RayCluster", cluster.Name, "service name", rayService.Name)
+		if err := dashboardClient.UpdateServeDeployments(ctx, config); err != nil {
+			clusterStatus.Message = err.Error()
+			r.Recorder.Eventf(rayService, corev1.EventTypeWarning, "FailedServeDeploy", "Failed to deploy the Serve config to RayCluster %s: %v", cluster.Name, err)
+			return false, err
+		}
+		clusterStatus.ServeConfigHash = configHash
+		r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "DeployedServeConfig", "Deployed the Serve config to RayCluster %s", cluster.Name)
+		// the deployments reported so far belong to the previous config
+		return false, nil
+	}
+
+	r.updateServeStatus(rayService, cluster, clusterStatus, serveStatus)
+	return isServeHealthy(rayService.Spec.ServeConfig, clusterStatus), nil
+}
+
+// updateServeStatus copies the health of the deployments reported by the dashboard into the status of the RayCluster
+func (r *RayServiceReconciler) updateServeStatus(rayService *rayiov1alpha1.RayService, cluster *rayiov1alpha1.RayCluster, clusterStatus *rayiov1alpha1.RayClusterServeStatus, serveStatus *dashboard.ServeStatus) {
+	clusterStatus.AppStatus = serveStatus.AppStatus.Status
+	clusterStatus.Message = serveStatus.AppStatus.Message
+
+	previous := map[string]rayiov1alpha1.ServeDeploymentStatus{}
+	for _, status := range clusterStatus.ServeDeploymentStatuses {
+		previous[status.Name] = status
+	}
+	now := metav1.Now()
+	statuses := make([]rayiov1alpha1.ServeDeploymentStatus, 0, len(serveStatus.DeploymentStatuses))
+	for _, reported := range serveStatus.DeploymentStatuses {
+		status := rayiov1alpha1.ServeDeploymentStatus{
+			Name:           reported.Name,
+			Status:         rayiov1alpha1.ServeDeploymentHealth(reported.Status),
+			Message:        reported.Message,
+			LastUpdateTime: &now,
+		}
+		if old, ok := previous[reported.Name]; ok && old.Status == status.Status {
+			// the time of the last change is kept
+			status.LastUpdateTime = old.LastUpdateTime
+		} else if status.Status == rayiov1alpha1.ServeDeploymentUnhealthy {
+			r.Recorder.Eventf(rayService, corev1.EventTypeWarning, "ServeDeploymentUnhealthy", "Serve deployment %s of RayCluster %s is unhealthy: %s", status.Name, cluster.Name, status.Message)
+		}
+		statuses = append(statuses, status)
+	}
+	clusterStatus.ServeDeploymentStatuses = statuses
+}
+
+// isServeHealthy returns true if the application is running and all its deployments, including the ones of the
+// config, are healthy
+func isServeHealthy(config rayiov1alpha1.ServeConfigSpec, clusterStatus *rayiov1alpha1.RayClusterServeStatus) bool {
+	if clusterStatus.AppStatus != rayiov1alpha1.ServeAppRunning || len(clusterStatus.ServeDeploymentStatuses) == 0 {
+		return false
+	}
+	healthy := map[string]bool{}
+	for _, status := range clusterStatus.ServeDeploymentStatuses {
+		if status.Status != rayiov1alpha1.ServeDeploymentHealthy {
+			return false
+		}
+		healthy[status.Name] = true
+	}
+	for _, deployment := range config.Deployments {
+		if !healthy[deployment.Name] {
+			return false
+		}
+	}
+	return true
+}
+
+// promotePendingCluster switches the Serve service to the pending RayCluster and deletes the active one
+func (r *RayServiceReconciler) promotePendingCluster(ctx context.Context, rayService *rayiov1alpha1.RayService, activeCluster *rayiov1alpha1.RayCluster, pendingCluster *rayiov1alpha1.RayCluster) error {
+	if err := r.reconcileServeService(ctx, rayService, pendingCluster); err != nil {
+		return err
+	}
+	serviceLog.Info("promotePendingCluster", "RayCluster", pendingCluster.Name, "service name", rayService.Name)
+	r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "PromotedRayCluster", "Serve deployments of RayCluster %s are healthy, it is now active", pendingCluster.Name)
+	rayService.Status.ActiveServiceStatus = rayService.Status.PendingServiceStatus
+	rayService.Status.PendingServiceStatus = rayiov1alpha1.RayClusterServeStatus{}
+	rayService.Status.ServiceStatus = rayiov1alpha1.RayServiceRunning
+
+	if activeCluster == nil {
+		return nil
+	}
+	serviceLog.Info("promotePendingCluster", "deleting RayCluster", activeCluster.Name, "service name", rayService.Name)
+	if err := r.Delete(ctx, activeCluster, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		// the outdated RayCluster is deleted by the next reconciliation
+		serviceLog.Error(err, "Delete RayCluster error", "cluster name", activeCluster.Name)
+		return nil
+	}
+	r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "DeletedRayCluster", "Deleted outdated RayCluster %s", activeCluster.Name)
+	return nil
+}
+
+// reconcileServeService creates the Serve service or switches its selector to the given RayCluster
+func (r *RayServiceReconciler) reconcileServeService(ctx context.Context, rayService *rayiov1alpha1.RayService, cluster *rayiov1alpha1.RayCluster) error {
+	desired := common.BuildServeServiceForRayService(*rayService, *cluster)
+	live := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, live)
+	if errors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(rayService, desired, r.Scheme); err != nil {
+			return err
+		}
+		serviceLog.Info("reconcileServeService", "creating Serve service", desired.Name, "cluster name", cluster.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "CreatedServeService", "Created Serve service %s selecting RayCluster %s", desired.Name, cluster.Name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	previousCluster := live.Spec.Selector[common.RayClusterLabelKey]
+	if !common.SyncHeadService(live, desired) {
+		return nil
+	}
+	serviceLog.Info("reconcileServeService", "updating Serve service", live.Name, "cluster name", cluster.Name)
+	if err := r.Update(ctx, live); err != nil {
+		return err
+	}
+	if previousCluster != cluster.Name {
+		r.Recorder.Eventf(rayService, corev1.EventTypeNormal, "SwitchedServeService", "Switched Serve service %s from RayCluster %s to %s", live.Name, previousCluster, cluster.Name)
+	}
+	return nil
+}
+
+// buildServeConfig converts the Serve config of the spec into the body of the Serve API of the dashboard
+func buildServeConfig(spec rayiov1alpha1.ServeConfigSpec) (*dashboard.ServeConfig, error) {
+	config := &dashboard.ServeConfig{ImportPath: spec.ImportPath}
+	if spec.RuntimeEnv != "" {
+		if err := json.Unmarshal([]byte(spec.RuntimeEnv), &config.RuntimeEnv); err != nil {
+			return nil, fmt.Errorf("runtimeEnv: %v", err)
+		}
+	}
+	for _, deployment := range spec.Deployments {
+		deploymentConfig := dashboard.ServeDeploymentConfig{
+			Name:                 deployment.Name,
+			NumReplicas:          deployment.NumReplicas,
+			RoutePrefix:          deployment.RoutePrefix,
+			MaxConcurrentQueries: deployment.MaxConcurrentQueries,
+		}
+		if deployment.UserConfig != "" {
+			if err := json.Unmarshal([]byte(deployment.UserConfig), &deploymentConfig.UserConfig); err != nil {
+				return nil, fmt.Errorf("userConfig of deployment %s: %v", deployment.Name, err)
+			}
+		}
+		config.Deployments = append(config.Deployments, deploymentConfig)
+	}
+	return config, nil
+}
+
+// SetupWithManager registers the RayService controller, it watches the RayClusters and Serve services of the services
+func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rayiov1alpha1.RayService{}).Named("rayservice-controller").
+		Owns(&rayiov1alpha1.RayCluster{}).
+		Owns(&corev1.Service{}).
+		Complete(r)
+}
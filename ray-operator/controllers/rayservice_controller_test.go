@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/dashboard"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRayServiceReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = rayiov1alpha1.AddToScheme(scheme)
+
+	rayService := &rayiov1alpha1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice-sample", Namespace: "default"},
+		Spec: rayiov1alpha1.RayServiceSpec{
+			ServeConfig: rayiov1alpha1.ServeConfigSpec{
+				ImportPath: "fruit.deployment_graph",
+				Deployments: []rayiov1alpha1.ServeDeploymentSpec{
+					{Name: "MangoStand", NumReplicas: pointer.Int32Ptr(1), UserConfig: `{"price": 3}`},
+				},
+			},
+			RayClusterSpec: rayiov1alpha1.RayClusterSpec{
+				HeadGroupSpec: rayiov1alpha1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:1.12.0"}}},
+					},
+				},
+			},
+		},
+	}
+	// one dashboard per RayCluster
+	dashboards := map[string]*fakeDashboardClient{}
+	r := &RayServiceReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(rayService).Build(),
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("controllers").WithName("RayService"),
+		Recorder: record.NewFakeRecorder(100),
+		DashboardClient: func(url string) dashboard.Client {
+			if _, ok := dashboards[url]; !ok {
+				dashboards[url] = &fakeDashboardClient{}
+			}
+			return dashboards[url]
+		},
+	}
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "rayservice-sample"}}
+	reconcileAndGet := func() *rayiov1alpha1.RayService {
+		if _, err := r.Reconcile(ctx, request); err != nil {
+			t.Fatalf("Failed to reconcile: %v", err)
+		}
+		current := &rayiov1alpha1.RayService{}
+		if err := r.Get(ctx, request.NamespacedName, current); err != nil {
+			t.Fatalf("Failed to get the RayService: %v", err)
+		}
+		return current
+	}
+	setHeadReady := func(name string) *fakeDashboardClient {
+		cluster := &rayiov1alpha1.RayCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, cluster); err != nil {
+			t.Fatalf("Failed to get the RayCluster: %v", err)
+		}
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type:   string(rayiov1alpha1.HeadPodReady),
+			Status: metav1.ConditionTrue,
+			Reason: "HeadPodRunning",
+		})
+		if err := r.Status().Update(ctx, cluster); err != nil {
+			t.Fatalf("Failed to update the RayCluster: %v", err)
+		}
+		return r.DashboardClient(common.GetDashboardURL(*cluster)).(*fakeDashboardClient)
+	}
+	serveSelector := func() string {
+		svc := &corev1.Service{}
+		key := types.NamespacedName{Namespace: "default", Name: utils.GenerateServeServiceName("rayservice-sample")}
+		if err := r.Get(ctx, key, svc); err != nil {
+			t.Fatalf("Failed to get the Serve service: %v", err)
+		}
+		return svc.Spec.Selector[common.RayClusterLabelKey]
+	}
+
+	// a first RayCluster is created, it is pending until its deployments are healthy
+	current := reconcileAndGet()
+	firstCluster := current.Status.PendingServiceStatus.RayClusterName
+	if firstCluster == "" || current.Status.ActiveServiceStatus.RayClusterName != "" {
+		t.Fatalf("Expected a pending RayCluster but got `%v`", current.Status)
+	}
+	if current.Status.ServiceStatus != rayiov1alpha1.RayServiceWaitForServeDeploymentReady {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.RayServiceWaitForServeDeploymentReady, current.Status.ServiceStatus)
+	}
+
+	// the Serve config is deployed once the head is ready
+	firstDashboard := setHeadReady(firstCluster)
+	current = reconcileAndGet()
+	if firstDashboard.serveConfig == nil || firstDashboard.serveConfig.Deployments[0].UserConfig["price"] != float64(3) {
+		t.Fatalf("Expected the Serve config to be deployed but got `%v`", firstDashboard.serveConfig)
+	}
+
+	// the RayCluster becomes active when its deployments are healthy
+	firstDashboard.setHealthy()
+	current = reconcileAndGet()
+	if current.Status.ActiveServiceStatus.RayClusterName != firstCluster || current.Status.PendingServiceStatus.RayClusterName != "" {
+		t.Fatalf("Expected `%v` to be active but got `%v`", firstCluster, current.Status)
+	}
+	if current.Status.ServiceStatus != rayiov1alpha1.RayServiceRunning {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.RayServiceRunning, current.Status.ServiceStatus)
+	}
+	if selector := serveSelector(); selector != firstCluster {
+		t.Fatalf("Expected `%v` but got `%v`", firstCluster, selector)
+	}
+
+	// a change of the Serve config is deployed in place
+	current.Spec.ServeConfig.Deployments[0].NumReplicas = pointer.Int32Ptr(2)
+	if err := r.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update the RayService: %v", err)
+	}
+	current = reconcileAndGet()
+	if *firstDashboard.serveConfig.Deployments[0].NumReplicas != 2 || current.Status.PendingServiceStatus.RayClusterName != "" {
+		t.Fatalf("Expected the Serve config to be deployed in place but got `%v`", current.Status)
+	}
+	firstDashboard.setHealthy()
+
+	// a change of the RayCluster spec brings up a new RayCluster while the first one keeps serving
+	current.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:2.0.0"
+	if err := r.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update the RayService: %v", err)
+	}
+	current = reconcileAndGet()
+	secondCluster := current.Status.PendingServiceStatus.RayClusterName
+	if secondCluster == "" || secondCluster == firstCluster {
+		t.Fatalf("Expected a new pending RayCluster but got `%v`", current.Status)
+	}
+	if current.Status.ServiceStatus != rayiov1alpha1.RayServicePreparingNewCluster {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.RayServicePreparingNewCluster, current.Status.ServiceStatus)
+	}
+	secondDashboard := setHeadReady(secondCluster)
+	reconcileAndGet()
+	if selector := serveSelector(); selector != firstCluster {
+		t.Fatalf("Expected `%v` but got `%v`", firstCluster, selector)
+	}
+
+	// an unreachable active RayCluster is reported in its status
+	firstDashboard.serveErr = fmt.Errorf("connection refused")
+	if _, err := r.Reconcile(ctx, request); err == nil {
+		t.Fatalf("Expected the error of the active RayCluster")
+	}
+	if err := r.Get(ctx, request.NamespacedName, current); err != nil {
+		t.Fatalf("Failed to get the RayService: %v", err)
+	}
+	if current.Status.ActiveServiceStatus.Message != "connection refused" || current.Status.ServiceStatus != rayiov1alpha1.RayServiceFailedServeDeploy {
+		t.Fatalf("Expected the active RayCluster to be failed but got `%v`", current.Status)
+	}
+
+	// the Serve service is switched once the new RayCluster is healthy, even if the active one is broken,
+	// and the first one is deleted
+	secondDashboard.setHealthy()
+	current = reconcileAndGet()
+	if current.Status.ActiveServiceStatus.RayClusterName != secondCluster {
+		t.Fatalf("Expected `%v` to be active but got `%v`", secondCluster, current.Status)
+	}
+	if selector := serveSelector(); selector != secondCluster {
+		t.Fatalf("Expected `%v` but got `%v`", secondCluster, selector)
+	}
+	cluster := &rayiov1alpha1.RayCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: "default", Name: firstCluster}, cluster); !errors.IsNotFound(err) {
+		t.Fatalf("Expected the first RayCluster to be deleted but got `%v`", err)
+	}
+}
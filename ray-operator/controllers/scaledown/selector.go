@@ -0,0 +1,101 @@
+package scaledown
+
+import (
+	"sort"
+	"strconv"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// lessFunc returns true if pod i should be removed before pod j
+type lessFunc func(i, j *corev1.Pod) bool
+
+// SelectPodsToDelete returns the count pods to remove from the candidates according to the policy.
+// An empty policy is treated as PendingFirst. The candidates are not modified.
+func SelectPodsToDelete(candidates []corev1.Pod, count int, policy rayiov1alpha1.ScaleDownPolicy) []corev1.Pod {
+	if count <= 0 {
+		return nil
+	}
+	pods := make([]corev1.Pod, len(candidates))
+	copy(pods, candidates)
+
+	var less lessFunc
+	switch policy {
+	case rayiov1alpha1.NewestFirstScaleDownPolicy:
+		less = newestFirst
+	case rayiov1alpha1.OldestFirstScaleDownPolicy:
+		less = oldestFirst
+	case rayiov1alpha1.DeletionCostScaleDownPolicy:
+		less = lowestDeletionCostFirst
+	default:
+		less = pendingFirst
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		if less(&pods[i], &pods[j]) {
+			return true
+		}
+		if less(&pods[j], &pods[i]) {
+			return false
+		}
+		// keep the selection deterministic
+		return pods[i].Name < pods[j].Name
+	})
+
+	if count > len(pods) {
+		count = len(pods)
+	}
+	return pods[:count]
+}
+
+// pendingFirst orders unscheduled < pending < unknown < running, then not ready < ready, then newest first
+func pendingFirst(i, j *corev1.Pod) bool {
+	if (i.Spec.NodeName == "") != (j.Spec.NodeName == "") {
+		return i.Spec.NodeName == ""
+	}
+	if phaseRank(i.Status.Phase) != phaseRank(j.Status.Phase) {
+		return phaseRank(i.Status.Phase) < phaseRank(j.Status.Phase)
+	}
+	if utils.IsRayContainerReady(i) != utils.IsRayContainerReady(j) {
+		return !utils.IsRayContainerReady(i)
+	}
+	return newestFirst(i, j)
+}
+
+func newestFirst(i, j *corev1.Pod) bool {
+	return j.CreationTimestamp.Before(&i.CreationTimestamp)
+}
+
+func oldestFirst(i, j *corev1.Pod) bool {
+	return i.CreationTimestamp.Before(&j.CreationTimestamp)
+}
+
+func lowestDeletionCostFirst(i, j *corev1.Pod) bool {
+	if deletionCost(i) != deletionCost(j) {
+		return deletionCost(i) < deletionCost(j)
+	}
+	return pendingFirst(i, j)
+}
+
+// deletionCost reads the ray.io/deletion-cost annotation, a missing or invalid value costs 0
+func deletionCost(pod *corev1.Pod) int64 {
+	cost, err := strconv.ParseInt(pod.Annotations[common.RayDeletionCostAnnotationKey], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return cost
+}
+
+func phaseRank(phase corev1.PodPhase) int {
+	switch phase {
+	case corev1.PodPending, "":
+		return 0
+	case corev1.PodUnknown:
+		return 1
+	case corev1.PodRunning:
+		return 2
+	}
+	return 3
+}
@@ -0,0 +1,84 @@
+package scaledown
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Now()
+
+func newPod(name string, age time.Duration, phase corev1.PodPhase, ready bool, cost string) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			Annotations:       map[string]string{},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-worker"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "ray-worker", Ready: ready}},
+		},
+	}
+	if phase != corev1.PodPending {
+		pod.Spec.NodeName = "node-1"
+	}
+	if cost != "" {
+		pod.Annotations[common.RayDeletionCostAnnotationKey] = cost
+	}
+	return pod
+}
+
+var candidates = []corev1.Pod{
+	newPod("old-running", 3*time.Hour, corev1.PodRunning, true, "10"),
+	newPod("new-running", time.Hour, corev1.PodRunning, true, ""),
+	newPod("pending", 2*time.Hour, corev1.PodPending, false, "5"),
+	newPod("not-ready", 4*time.Hour, corev1.PodRunning, false, "-1"),
+}
+
+func names(pods []corev1.Pod) []string {
+	var result []string
+	for _, pod := range pods {
+		result = append(result, pod.Name)
+	}
+	return result
+}
+
+func TestSelectPodsToDelete(t *testing.T) {
+	tests := map[rayiov1alpha1.ScaleDownPolicy][]string{
+		"": {"pending", "not-ready", "new-running"},
+		rayiov1alpha1.PendingFirstScaleDownPolicy: {"pending", "not-ready", "new-running"},
+		rayiov1alpha1.NewestFirstScaleDownPolicy:  {"new-running", "pending", "old-running"},
+		rayiov1alpha1.OldestFirstScaleDownPolicy:  {"not-ready", "old-running", "pending"},
+		rayiov1alpha1.DeletionCostScaleDownPolicy: {"not-ready", "new-running", "pending"},
+	}
+
+	for policy, expected := range tests {
+		actual := names(SelectPodsToDelete(candidates, 3, policy))
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("%s: Expected `%v` but got `%v`", policy, expected, actual)
+		}
+	}
+
+	// the candidates are left untouched
+	if candidates[0].Name != "old-running" {
+		t.Fatalf("Expected `%v` but got `%v`", "old-running", candidates[0].Name)
+	}
+}
+
+func TestSelectPodsToDeleteCount(t *testing.T) {
+	if pods := SelectPodsToDelete(candidates, 0, rayiov1alpha1.PendingFirstScaleDownPolicy); len(pods) != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, len(pods))
+	}
+	if pods := SelectPodsToDelete(candidates, 10, rayiov1alpha1.PendingFirstScaleDownPolicy); len(pods) != len(candidates) {
+		t.Fatalf("Expected `%v` but got `%v`", len(candidates), len(pods))
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 
 	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
@@ -12,8 +14,36 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+const (
+	// HeadRecoveryInitialBackoff is the delay between the first and the second recovery of the head pod
+	HeadRecoveryInitialBackoff = 10 * time.Second
+	// HeadRecoveryMaxBackoff caps the delay between two recoveries of the head pod
+	HeadRecoveryMaxBackoff = 5 * time.Minute
+
+	// WorkerRecreationInitialBackoff is the delay before replacing the first terminated worker of a group
+	WorkerRecreationInitialBackoff = 5 * time.Second
+	// WorkerRecreationMaxBackoff caps the delay before replacing the terminated workers of a group
+	WorkerRecreationMaxBackoff = 5 * time.Minute
+	// DefaultCrashLoopThreshold is the default number of failed workers within the window flagging a group as crash looping
+	DefaultCrashLoopThreshold = 5
+	// DefaultCrashLoopWindow is the default window in which the failed workers of a group are counted
+	DefaultCrashLoopWindow = 10 * time.Minute
+)
+
+// CrashLoopPolicy flags a worker group as crash looping when Threshold of its workers failed within Window
+type CrashLoopPolicy struct {
+	Threshold int32
+	Window    time.Duration
+}
+
+// DefaultCrashLoopPolicy returns the policy of the operator when it is not configured
+func DefaultCrashLoopPolicy() CrashLoopPolicy {
+	return CrashLoopPolicy{Threshold: DefaultCrashLoopThreshold, Window: DefaultCrashLoopWindow}
+}
+
 // IsCreated returns true if pod has been created and is maintained by the API server
 func IsCreated(pod *corev1.Pod) bool {
 	return pod.Status.Phase != ""
@@ -93,6 +123,21 @@ func GenerateServiceName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayiov1alpha1.HeadNode, "svc")
 }
 
+// GenerateServeServiceName generates the name of the stable Serve service of a RayService
+func GenerateServeServiceName(serviceName string) string {
+	return fmt.Sprintf("%s-%s-%s", serviceName, "serve", "svc")
+}
+
+// GeneratePodDisruptionBudgetName generates the name of the PodDisruptionBudget of a group of the cluster
+func GeneratePodDisruptionBudgetName(clusterName string, groupName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", clusterName, groupName, "pdb"))
+}
+
+// GenerateNetworkPolicyName generates the name of the NetworkPolicy of the cluster
+func GenerateNetworkPolicyName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, "network-policy"))
+}
+
 // GenerateIdentifier generates identifier of same group pods
 func GenerateIdentifier(clusterName string, nodeType rayiov1alpha1.RayNodeType) string {
 	return fmt.Sprintf("%s-%s", clusterName, nodeType)
@@ -149,3 +194,206 @@ func CalculateAvailableReplicas(pods corev1.PodList) int32 {
 
 	return count
 }
+
+// IsSuspended returns true if the pods of the cluster must be deleted
+func IsSuspended(cluster *rayiov1alpha1.RayCluster) bool {
+	return cluster.Spec.Suspend != nil && *cluster.Spec.Suspend
+}
+
+// CalculateClusterState derives the overall state of the cluster from the head pod and the running workers.
+// It returns the state together with a human readable reason explaining it.
+func CalculateClusterState(headPod *corev1.Pod, workerPods corev1.PodList, desiredWorkers int32) (rayiov1alpha1.ClusterState, string) {
+	if headPod == nil {
+		return rayiov1alpha1.UnHealthy, "head pod not found"
+	}
+
+	switch headPod.Status.Phase {
+	case corev1.PodFailed, corev1.PodSucceeded:
+		return rayiov1alpha1.Failed, fmt.Sprintf("head pod %s is in %s phase", headPod.Name, headPod.Status.Phase)
+	case corev1.PodRunning:
+		// checked below
+	default:
+		return rayiov1alpha1.UnHealthy, fmt.Sprintf("head pod %s is pending", headPod.Name)
+	}
+
+	if !IsRayContainerReady(headPod) {
+		return rayiov1alpha1.UnHealthy, fmt.Sprintf("ray container of head pod %s is not ready", headPod.Name)
+	}
+
+	runningWorkers := int32(0)
+	for _, pod := range workerPods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			runningWorkers++
+		}
+	}
+	if runningWorkers < desiredWorkers {
+		return rayiov1alpha1.UnHealthy, fmt.Sprintf("%d/%d workers are running", runningWorkers, desiredWorkers)
+	}
+
+	return rayiov1alpha1.Ready, ""
+}
+
+// IsRayContainerReady returns true if the ray container of the pod reports ready
+func IsRayContainerReady(pod *corev1.Pod) bool {
+	if len(pod.Spec.Containers) == 0 {
+		return false
+	}
+	index := FindRayContainerIndex(pod.Spec)
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == pod.Spec.Containers[index].Name {
+			return status.Ready
+		}
+	}
+	return false
+}
+
+// CalculateRollingUpdate returns how many new pods can be created and how many available outdated pods can be deleted
+// in one step of a rolling update, given the total, updated and available pods of a group.
+func CalculateRollingUpdate(replicas int32, total int32, updated int32, available int32, maxSurge int32, maxUnavailable int32) (toCreate int32, toDelete int32) {
+	if maxSurge == 0 && maxUnavailable == 0 {
+		// the update could never progress
+		maxUnavailable = 1
+	}
+
+	toCreate = replicas + maxSurge - total
+	if missing := replicas - updated; toCreate > missing {
+		toCreate = missing
+	}
+	if toCreate < 0 {
+		toCreate = 0
+	}
+
+	toDelete = available - (replicas - maxUnavailable)
+	if toDelete < 0 {
+		toDelete = 0
+	}
+	return toCreate, toDelete
+}
+
+// GetRollingUpdateLimits resolves maxSurge and maxUnavailable of the worker group strategy, defaulting them to 25%
+func GetRollingUpdateLimits(worker rayiov1alpha1.WorkerGroupSpec) (maxSurge int32, maxUnavailable int32, err error) {
+	defaultValue := intstr.FromString("25%")
+	surge, unavailable := &defaultValue, &defaultValue
+	if rollingUpdate := worker.UpdateStrategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			surge = rollingUpdate.MaxSurge
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			unavailable = rollingUpdate.MaxUnavailable
+		}
+	}
+
+	replicas := int(*worker.Replicas)
+	surgeValue, err := intstr.GetValueFromIntOrPercent(surge, replicas, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	unavailableValue, err := intstr.GetValueFromIntOrPercent(unavailable, replicas, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int32(surgeValue), int32(unavailableValue), nil
+}
+
+// GetHeadRecoveryDelay returns how long to wait before recreating a terminated head pod. The backoff doubles with
+// each restart and is measured from the last one, so a head failing long after its last recovery is recreated at once.
+func GetHeadRecoveryDelay(status rayiov1alpha1.RayClusterStatus, now time.Time) time.Duration {
+	return getBackoffDelay(status.HeadRestarts, status.LastHeadRestartTime, now, HeadRecoveryInitialBackoff, HeadRecoveryMaxBackoff)
+}
+
+// GetWorkerRecreationDelay returns how long to wait before replacing the terminated workers of a group,
+// the backoff doubles with each recent failure and is measured from the last one
+func GetWorkerRecreationDelay(status rayiov1alpha1.WorkerGroupStatus, now time.Time) time.Duration {
+	return getBackoffDelay(status.RecentFailures, status.LastFailureTime, now, WorkerRecreationInitialBackoff, WorkerRecreationMaxBackoff)
+}
+
+func getBackoffDelay(attempts int32, last *metav1.Time, now time.Time, initial time.Duration, max time.Duration) time.Duration {
+	if attempts == 0 || last == nil {
+		return 0
+	}
+	backoff := initial
+	for i := int32(1); i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	if remaining := last.Add(backoff).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// RecordWorkerFailures counts the failed workers of a group and flags it as crash looping when policy.Threshold
+// workers failed within policy.Window. It returns true if the group started crash looping.
+func RecordWorkerFailures(status *rayiov1alpha1.WorkerGroupStatus, failures int32, policy CrashLoopPolicy, now time.Time) bool {
+	nowTime := metav1.NewTime(now)
+	if status.FailureWindowStart == nil || now.Sub(status.FailureWindowStart.Time) > policy.Window {
+		status.FailureWindowStart = &nowTime
+		status.RecentFailures = 0
+	}
+	status.Failures += failures
+	status.RecentFailures += failures
+	status.LastFailureTime = &nowTime
+	if status.RecentFailures >= policy.Threshold && !status.CrashLooping {
+		status.CrashLooping = true
+		return true
+	}
+	return false
+}
+
+// ExpireWorkerFailures forgets the recent failures of a group without failure for policy.Window.
+// It returns true if the group stopped crash looping.
+func ExpireWorkerFailures(status *rayiov1alpha1.WorkerGroupStatus, policy CrashLoopPolicy, now time.Time) bool {
+	if status.LastFailureTime == nil || now.Sub(status.LastFailureTime.Time) <= policy.Window {
+		return false
+	}
+	wasCrashLooping := status.CrashLooping
+	status.RecentFailures = 0
+	status.FailureWindowStart = nil
+	status.CrashLooping = false
+	return wasCrashLooping
+}
+
+// SlowStartBatch calls fn count times in parallel batches of growing size, starting with 1 and doubling after each
+// batch up to maxBatchSize. It stops after the first batch with an error, so a failure repeated by every call
+// (e.g. an exhausted quota) is only hit a few times. It returns the number of successful calls and their errors.
+func SlowStartBatch(count int, maxBatchSize int, fn func() error) (int, []error) {
+	if maxBatchSize < 1 {
+		maxBatchSize = 1
+	}
+	remaining := count
+	successes := 0
+	for batchSize := 1; remaining > 0; batchSize *= 2 {
+		if batchSize > maxBatchSize {
+			batchSize = maxBatchSize
+		}
+		if batchSize > remaining {
+			batchSize = remaining
+		}
+		errCh := make(chan error, batchSize)
+		var wg sync.WaitGroup
+		wg.Add(batchSize)
+		for i := 0; i < batchSize; i++ {
+			go func() {
+				defer wg.Done()
+				if err := fn(); err != nil {
+					errCh <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errCh)
+
+		var errs []error
+		for err := range errCh {
+			errs = append(errs, err)
+		}
+		successes += batchSize - len(errs)
+		if len(errs) > 0 {
+			return successes, errs
+		}
+		remaining -= batchSize
+	}
+	return successes, nil
+}
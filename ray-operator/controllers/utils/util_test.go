@@ -1,9 +1,16 @@
 package utils
 
 import (
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	rayiov1alpha1 "github.com/ray-project/kuberay/ray-operator/api/raycluster/v1alpha1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
@@ -55,6 +62,183 @@ func TestCheckName(t *testing.T) {
 	}
 }
 
+func TestCalculateClusterState(t *testing.T) {
+	workerPods := corev1.PodList{Items: []corev1.Pod{*createSomePod()}}
+	workerPods.Items[0].Status.Phase = v1.PodRunning
+
+	state, _ := CalculateClusterState(nil, workerPods, 1)
+	if state != rayiov1alpha1.UnHealthy {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.UnHealthy, state)
+	}
+
+	headPod := createSomePod()
+	headPod.Spec.Containers = []corev1.Container{{Name: "ray-head"}}
+	headPod.Status.Phase = v1.PodFailed
+	state, _ = CalculateClusterState(headPod, workerPods, 1)
+	if state != rayiov1alpha1.Failed {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.Failed, state)
+	}
+
+	headPod.Status.Phase = v1.PodRunning
+	state, _ = CalculateClusterState(headPod, workerPods, 1)
+	if state != rayiov1alpha1.UnHealthy {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.UnHealthy, state)
+	}
+
+	headPod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "ray-head", Ready: true}}
+	state, _ = CalculateClusterState(headPod, workerPods, 2)
+	if state != rayiov1alpha1.UnHealthy {
+		t.Fatalf("Expected `%v` but got `%v`", rayiov1alpha1.UnHealthy, state)
+	}
+
+	state, reason := CalculateClusterState(headPod, workerPods, 1)
+	if state != rayiov1alpha1.Ready || reason != "" {
+		t.Fatalf("Expected `%v` but got `%v` (%s)", rayiov1alpha1.Ready, state, reason)
+	}
+}
+
+func TestCalculateRollingUpdate(t *testing.T) {
+	// 3 outdated running pods, no surge: one pod is deleted first
+	toCreate, toDelete := CalculateRollingUpdate(3, 3, 0, 3, 0, 1)
+	if toCreate != 0 || toDelete != 1 {
+		t.Fatalf("Expected `0, 1` but got `%v, %v`", toCreate, toDelete)
+	}
+	// then its replacement is created while the new pod is not available yet
+	toCreate, toDelete = CalculateRollingUpdate(3, 2, 0, 2, 0, 1)
+	if toCreate != 1 || toDelete != 0 {
+		t.Fatalf("Expected `1, 0` but got `%v, %v`", toCreate, toDelete)
+	}
+	// with surge, new pods are created before any deletion
+	toCreate, toDelete = CalculateRollingUpdate(4, 4, 0, 4, 1, 0)
+	if toCreate != 1 || toDelete != 0 {
+		t.Fatalf("Expected `1, 0` but got `%v, %v`", toCreate, toDelete)
+	}
+	// both limits at zero would block the update
+	toCreate, toDelete = CalculateRollingUpdate(2, 2, 0, 2, 0, 0)
+	if toCreate != 0 || toDelete != 1 {
+		t.Fatalf("Expected `0, 1` but got `%v, %v`", toCreate, toDelete)
+	}
+}
+
+func TestGetRollingUpdateLimits(t *testing.T) {
+	worker := rayiov1alpha1.WorkerGroupSpec{Replicas: pointer.Int32Ptr(10)}
+	maxSurge, maxUnavailable, err := GetRollingUpdateLimits(worker)
+	if err != nil || maxSurge != 3 || maxUnavailable != 2 {
+		t.Fatalf("Expected `3, 2` but got `%v, %v` (%v)", maxSurge, maxUnavailable, err)
+	}
+
+	surge := intstr.FromInt(0)
+	unavailable := intstr.FromString("50%")
+	worker.UpdateStrategy.RollingUpdate = &rayiov1alpha1.RollingUpdateStrategy{MaxSurge: &surge, MaxUnavailable: &unavailable}
+	maxSurge, maxUnavailable, err = GetRollingUpdateLimits(worker)
+	if err != nil || maxSurge != 0 || maxUnavailable != 5 {
+		t.Fatalf("Expected `0, 5` but got `%v, %v` (%v)", maxSurge, maxUnavailable, err)
+	}
+}
+
+func TestGetHeadRecoveryDelay(t *testing.T) {
+	now := time.Now()
+	status := rayiov1alpha1.RayClusterStatus{}
+	if delay := GetHeadRecoveryDelay(status, now); delay != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, delay)
+	}
+
+	lastRestart := metav1.NewTime(now.Add(-5 * time.Second))
+	status.LastHeadRestartTime = &lastRestart
+	status.HeadRestarts = 1
+	if delay := GetHeadRecoveryDelay(status, now); delay != 5*time.Second {
+		t.Fatalf("Expected `%v` but got `%v`", 5*time.Second, delay)
+	}
+	status.HeadRestarts = 3
+	if delay := GetHeadRecoveryDelay(status, now); delay != 35*time.Second {
+		t.Fatalf("Expected `%v` but got `%v`", 35*time.Second, delay)
+	}
+	// the backoff is capped
+	status.HeadRestarts = 100
+	if delay := GetHeadRecoveryDelay(status, now); delay != HeadRecoveryMaxBackoff-5*time.Second {
+		t.Fatalf("Expected `%v` but got `%v`", HeadRecoveryMaxBackoff-5*time.Second, delay)
+	}
+	// the head failed long after its last recovery
+	lastRestart = metav1.NewTime(now.Add(-time.Hour))
+	if delay := GetHeadRecoveryDelay(status, now); delay != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, delay)
+	}
+}
+
+func TestWorkerFailures(t *testing.T) {
+	now := time.Now()
+	status := rayiov1alpha1.WorkerGroupStatus{GroupName: "small-group"}
+	policy := DefaultCrashLoopPolicy()
+	if RecordWorkerFailures(&status, 2, policy, now) {
+		t.Fatalf("Expected the group not to be crash looping after 2 failures")
+	}
+	if delay := GetWorkerRecreationDelay(status, now); delay != 2*WorkerRecreationInitialBackoff {
+		t.Fatalf("Expected `%v` but got `%v`", 2*WorkerRecreationInitialBackoff, delay)
+	}
+	if !RecordWorkerFailures(&status, 3, policy, now.Add(time.Minute)) {
+		t.Fatalf("Expected the group to be crash looping after 5 failures")
+	}
+	if RecordWorkerFailures(&status, 1, policy, now.Add(2*time.Minute)) {
+		t.Fatalf("Expected the crash loop to be reported once")
+	}
+	if status.Failures != 6 || status.RecentFailures != 6 {
+		t.Fatalf("Expected `6, 6` but got `%v, %v`", status.Failures, status.RecentFailures)
+	}
+
+	// the group is still crash looping within the window
+	if ExpireWorkerFailures(&status, policy, now.Add(5*time.Minute)) {
+		t.Fatalf("Expected the group to be crash looping")
+	}
+	if !ExpireWorkerFailures(&status, policy, now.Add(2*time.Minute+policy.Window+time.Second)) {
+		t.Fatalf("Expected the group to recover")
+	}
+	if status.Failures != 6 || status.RecentFailures != 0 || status.CrashLooping {
+		t.Fatalf("Expected `6, 0, false` but got `%v, %v, %v`", status.Failures, status.RecentFailures, status.CrashLooping)
+	}
+	if delay := GetWorkerRecreationDelay(status, now); delay != 0 {
+		t.Fatalf("Expected `%v` but got `%v`", 0, delay)
+	}
+
+	// a stricter policy flags the group sooner and keeps it crash looping longer
+	policy = CrashLoopPolicy{Threshold: 2, Window: time.Hour}
+	status = rayiov1alpha1.WorkerGroupStatus{GroupName: "small-group"}
+	if !RecordWorkerFailures(&status, 2, policy, now) {
+		t.Fatalf("Expected the group to be crash looping after 2 failures")
+	}
+	if ExpireWorkerFailures(&status, policy, now.Add(30*time.Minute)) {
+		t.Fatalf("Expected the group to be crash looping")
+	}
+}
+
+func TestSlowStartBatch(t *testing.T) {
+	var calls int32
+	successes, errs := SlowStartBatch(10, 4, func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	// batches of 1, 2, 4 and 3
+	if successes != 10 || len(errs) != 0 || calls != 10 {
+		t.Fatalf("Expected `10, 0, 10` but got `%v, %v, %v`", successes, len(errs), calls)
+	}
+
+	// the third call fails, it is part of the second batch of 2 calls
+	calls = 0
+	successes, errs = SlowStartBatch(10, 4, func() error {
+		if atomic.AddInt32(&calls, 1) == 3 {
+			return fmt.Errorf("exceeded quota")
+		}
+		return nil
+	})
+	if successes != 2 || len(errs) != 1 || calls != 3 {
+		t.Fatalf("Expected `2, 1, 3` but got `%v, %v, %v`", successes, len(errs), calls)
+	}
+
+	successes, errs = SlowStartBatch(0, 4, func() error { return nil })
+	if successes != 0 || len(errs) != 0 {
+		t.Fatalf("Expected `0, 0` but got `%v, %v`", successes, len(errs))
+	}
+}
+
 func createSomePod() (pod *corev1.Pod) {
 
 	return &corev1.Pod{
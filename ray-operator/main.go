@@ -4,10 +4,13 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers"
+	"github.com/ray-project/kuberay/ray-operator/controllers/idle"
+	"github.com/ray-project/kuberay/ray-operator/controllers/utils"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -39,18 +42,36 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var reconcileConcurrency int
+	var maxCreationBatchSize int
 	var watchNamespace string
+	var enableWebhooks bool
+	var idleProbe string
+	var expirationWarningPeriod time.Duration
+	var crashLoopThreshold int
+	var crashLoopWindow time.Duration
 	flag.BoolVar(&version, "version", false, "Show the version information.")
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8082", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	flag.IntVar(&reconcileConcurrency, "reconcile-concurrency", 1, "max concurrency for reconciling")
+	flag.IntVar(&maxCreationBatchSize, "max-creation-batch-size", controllers.DefaultMaxCreationBatchSize,
+		"Max number of worker pods created in parallel. The pods are created in batches of 1, 2, 4, ... up to this size.")
 	flag.StringVar(
 		&watchNamespace,
 		"watch-namespace",
 		"",
 		"Watch custom resources in the namespace, ignore other namespaces. If empty, all namespaces will be watched.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable the defaulting and validating webhooks of RayCluster. The serving certificates must be mounted in /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&idleProbe, "idle-probe", "annotation",
+		"How the activity of the clusters with an idle timeout is detected: annotation reads the ray.io/last-activity-time annotation, dashboard asks the job server of the head.")
+	flag.DurationVar(&expirationWarningPeriod, "expiration-warning-period", controllers.DefaultExpirationWarningPeriod,
+		"How long before the TTL or the idle timeout of a cluster expires warning events are emitted.")
+	flag.IntVar(&crashLoopThreshold, "crash-loop-threshold", utils.DefaultCrashLoopThreshold,
+		"Number of failed workers within --crash-loop-window flagging a worker group as crash looping.")
+	flag.DurationVar(&crashLoopWindow, "crash-loop-window", utils.DefaultCrashLoopWindow,
+		"Window in which the failed workers of a group are counted. A group is no longer crash looping after a window without failure.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -81,10 +102,36 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = controllers.NewReconciler(mgr).SetupWithManager(mgr, reconcileConcurrency); err != nil {
+	reconciler := controllers.NewReconciler(mgr)
+	reconciler.MaxCreationBatchSize = maxCreationBatchSize
+	reconciler.ExpirationWarningPeriod = expirationWarningPeriod
+	if crashLoopThreshold < 1 || crashLoopWindow <= 0 {
+		setupLog.Error(fmt.Errorf("threshold %d, window %s", crashLoopThreshold, crashLoopWindow), "the crash loop threshold and window must be positive")
+		os.Exit(1)
+	}
+	reconciler.CrashLoopPolicy = utils.CrashLoopPolicy{Threshold: int32(crashLoopThreshold), Window: crashLoopWindow}
+	if reconciler.IdleProbe, err = idle.NewProbe(idleProbe); err != nil {
+		setupLog.Error(err, "invalid idle probe")
+		os.Exit(1)
+	}
+	if err = reconciler.SetupWithManager(mgr, reconcileConcurrency); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RayCluster")
 		os.Exit(1)
 	}
+	if err = controllers.NewRayJobReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RayJob")
+		os.Exit(1)
+	}
+	if err = controllers.NewRayServiceReconciler(mgr).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RayService")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		if err = (&rayiov1alpha1.RayCluster{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RayCluster")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {